@@ -27,7 +27,9 @@ along with this program.  If not, see http://www.gnu.org/licenses/.
 package main
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	fileUtils "github.com/edoardottt/cariddi/internal/file"
 	sliceUtils "github.com/edoardottt/cariddi/internal/slice"
@@ -39,6 +41,32 @@ import (
 
 // main function.
 func main() {
+	// Turn the JSON output of a scan into a baseline and exit.
+	if len(os.Args) > 1 && os.Args[1] == input.BaselineCommand {
+		baselineFlags := input.ScanBaselineFlags(os.Args[2:])
+		input.CheckBaselineFlags(baselineFlags)
+
+		if err := output.CreateBaseline(baselineFlags); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	// Compare the JSON outputs of two scans and exit.
+	if len(os.Args) > 1 && os.Args[1] == input.DiffCommand {
+		diffFlags := input.ScanDiffFlags(os.Args[2:])
+		input.CheckDiffFlags(diffFlags)
+
+		if err := output.CreateDiff(diffFlags); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
 	// Scan flags.
 	flags := input.ScanFlag()
 
@@ -87,6 +115,27 @@ func main() {
 		Debug:         flags.Debug,
 		UserAgent:     flags.UserAgent,
 		StoreResp:     flags.StoreResp,
+		Redact:        flags.Redact,
+		RedactHash:    flags.RedactHash,
+		DecodeDepth:   flags.Decode,
+		SecHeaders:    flags.SecHeaders,
+		CookiesFlag:   flags.Cookies,
+		CORSFlag:      flags.CORS,
+		TechFlag:      flags.Tech,
+		TechFile:      flags.TechFile,
+		JSLibsFlag:    flags.JSLibs,
+		JSLibsFile:    flags.JSLibsFile,
+		HostsFlag:     flags.Hosts,
+		CloudFlag:     flags.Cloud,
+		CloudCheck:    flags.CloudCheck,
+		CloudEndpoint: flags.CloudEndpoint,
+		APIFlag:       flags.API,
+		SpecFile:      flags.SpecFile,
+		XSSFlag:       flags.XSS,
+		RedirFlag:     flags.Redir,
+		RedirURL:      flags.RedirURL,
+		BackupFlag:    flags.Backup,
+		MinSeverity:   flags.MinSeverity,
 	}
 
 	// Read the targets from standard input.
@@ -101,31 +150,35 @@ func main() {
 		config.EndpointsSlice = fileUtils.ReadFile(flags.EndpointsFile)
 	}
 
+	// If it is needed, read custom paths definition
+	// from the specified file.
+	if flags.PathsFile != "" {
+		config.PathsSlice = fileUtils.ReadFile(flags.PathsFile)
+	}
+
 	// If it is needed, read custom secrets definition
 	// from the specified file.
 	if flags.SecretsFile != "" {
 		config.SecretsSlice = fileUtils.ReadFile(flags.SecretsFile)
 	}
 
-	finalResults := []string{}
-	finalSecret := []scanner.SecretMatched{}
-	finalEndpoints := []scanner.EndpointMatched{}
-	finalExtensions := []scanner.FileTypeMatched{}
-	finalErrors := []scanner.ErrorMatched{}
-	finalInfos := []scanner.InfoMatched{}
+	// If it is needed, read the accepted and false positive
+	// findings from the specified baseline file.
+	if flags.Baseline != "" {
+		baseline, err := scanner.ParseBaseline(fileUtils.ReadEntireFile(flags.Baseline))
+		if err != nil {
+			fmt.Println("The baseline file " + flags.Baseline + " is not valid: " + err.Error())
+			os.Exit(1)
+		}
 
-	// Create output files if needed (txt / html).
-	config.Txt = ""
-	if flags.TXTout != "" {
-		config.Txt = fileUtils.CreateOutputFile(flags.TXTout, "results", "txt")
+		config.Baseline = baseline
 	}
 
-	var ResultHTML = ""
-	if flags.HTMLout != "" {
-		ResultHTML = fileUtils.CreateOutputFile(flags.HTMLout, "", "html")
-		output.BannerHTML(ResultHTML)
-		output.HeaderHTML("Results", ResultHTML)
-	}
+	finalResults := &scanner.Results{}
+
+	// Enable the writers of the outputs requested (stdout / json / txt / html...).
+	writers := output.NewWriters(flags)
+	config.Writers = writers
 
 	if config.StoreResp {
 		fileUtils.CreateIndexOutputFile("index.responses.txt")
@@ -144,79 +197,54 @@ func main() {
 	}
 
 	// For each target generate a crawler and collect all the results.
+	start := time.Now()
+
 	for _, target := range targets {
 		config.Target = target
 		results := crawler.New(config)
-		finalResults = append(finalResults, results.URLs...)
-		finalSecret = append(finalSecret, results.Secrets...)
-		finalEndpoints = append(finalEndpoints, results.Endpoints...)
-		finalExtensions = append(finalExtensions, results.Extensions...)
-		finalErrors = append(finalErrors, results.Errors...)
-		finalInfos = append(finalInfos, results.Infos...)
+		finalResults.Append(results)
 	}
 
-	// Remove duplicates from all the results.
-	finalResults = sliceUtils.RemoveDuplicateValues(finalResults)
-	finalSecret = scanner.RemoveDuplicateSecrets(finalSecret)
-	finalEndpoints = scanner.RemovDuplicateEndpoints(finalEndpoints)
-	finalExtensions = scanner.RemoveDuplicateExtensions(finalExtensions)
-	finalErrors = scanner.RemoveDuplicateErrors(finalErrors)
-	finalInfos = scanner.RemoveDuplicateInfos(finalInfos)
+	end := time.Now()
 
-	// IF TXT OUTPUT >
-	if flags.TXTout != "" {
-		output.TxtOutput(flags, finalResults, finalSecret, finalEndpoints,
-			finalExtensions, finalErrors, finalInfos)
-	}
-
-	// IF HTML OUTPUT >
-	if flags.HTMLout != "" {
-		output.HTMLOutput(flags, ResultHTML, finalResults, finalSecret,
-			finalEndpoints, finalExtensions, finalErrors, finalInfos)
-	}
-
-	// If needed print secrets.
-	if !flags.JSON && !flags.Plain && len(finalSecret) != 0 {
-		for _, elem := range finalSecret {
-			output.EncapsulateCustomGreen(elem.Secret.Name, elem.Match+" in "+elem.URL)
-		}
-	}
-
-	// If needed print endpoints.
-	if !flags.JSON && !flags.Plain && len(finalEndpoints) != 0 {
-		for _, elem := range finalEndpoints {
-			for _, parameter := range elem.Parameters {
-				finalString := "" + parameter.Parameter
-				if len(parameter.Attacks) != 0 {
-					finalString += " -"
-					for _, attack := range parameter.Attacks {
-						finalString += " " + attack
-					}
-				}
-
-				output.EncapsulateCustomGreen(finalString, " in "+elem.URL)
-			}
-		}
-	}
-
-	// If needed print extensions.
-	if !flags.JSON && !flags.Plain && len(finalExtensions) != 0 {
-		for _, elem := range finalExtensions {
-			output.EncapsulateCustomGreen(elem.Filetype.Extension, elem.URL+" matched!")
-		}
-	}
-
-	// If needed print errors.
-	if !flags.JSON && !flags.Plain && len(finalErrors) != 0 {
-		for _, elem := range finalErrors {
-			output.EncapsulateCustomGreen(elem.Error.ErrorName, elem.Match+" in "+elem.URL)
-		}
-	}
-
-	// If needed print infos.
-	if !flags.JSON && !flags.Plain && len(finalInfos) != 0 {
-		for _, elem := range finalInfos {
-			output.EncapsulateCustomGreen(elem.Info.Name, elem.Match+" in "+elem.URL)
-		}
+	// Remove duplicates from all the results.
+	finalResults.URLs = sliceUtils.RemoveDuplicateValues(finalResults.URLs)
+	finalResults.Secrets = scanner.RemoveDuplicateSecrets(finalResults.Secrets)
+	finalResults.Endpoints = scanner.RemovDuplicateEndpoints(finalResults.Endpoints)
+	finalResults.Extensions = scanner.RemoveDuplicateExtensions(finalResults.Extensions)
+	finalResults.Errors = scanner.RemoveDuplicateErrors(finalResults.Errors)
+	finalResults.Infos = scanner.RemoveDuplicateInfos(finalResults.Infos)
+	finalResults.JWTs = scanner.RemoveDuplicateJWTs(finalResults.JWTs)
+	finalResults.Headers = scanner.RemoveDuplicateHeaders(finalResults.Headers)
+	finalResults.Cookies = scanner.RemoveDuplicateCookies(finalResults.Cookies)
+	finalResults.CORS = scanner.RemoveDuplicateCORS(finalResults.CORS)
+	finalResults.Technologies = scanner.RemoveDuplicateTechnologies(finalResults.Technologies)
+	finalResults.JSLibraries = scanner.RemoveDuplicateJSLibraries(finalResults.JSLibraries)
+	finalResults.Hosts = scanner.RemoveDuplicateHosts(finalResults.Hosts)
+	finalResults.Buckets = scanner.RemoveDuplicateBuckets(finalResults.Buckets)
+	finalResults.APIs = scanner.RemoveDuplicateAPIs(finalResults.APIs)
+	finalResults.Reflections = scanner.RemoveDuplicateReflections(finalResults.Reflections)
+	finalResults.Redirects = scanner.RemoveDuplicateRedirects(finalResults.Redirects)
+	finalResults.Exposures = scanner.RemoveDuplicateExposures(finalResults.Exposures)
+
+	// Drop the findings already triaged in the baseline.
+	finalResults.Suppress(config.Baseline, time.Now())
+
+	// Keep the findings with at least the minimum severity, the most severe first.
+	finalResults.Filter(flags.MinSeverity)
+	finalResults.Sort()
+
+	// Mask the secrets, the tokens and the cookie values before writing them anywhere.
+	if flags.Redact {
+		finalResults.Secrets = scanner.RedactSecrets(finalResults.Secrets, flags.RedactHash)
+		finalResults.JWTs = scanner.RedactJWTs(finalResults.JWTs, flags.RedactHash)
+		finalResults.Cookies = scanner.RedactCookies(finalResults.Cookies, flags.RedactHash)
+	}
+
+	// Write the results with all the writers enabled.
+	info := &output.ScanInfo{Targets: targets, Options: flags, StartTime: start, EndTime: end}
+	if err := writers.WriteResults(info, finalResults); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
 	}
 }
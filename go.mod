@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/fatih/color v1.16.0
 	github.com/gocolly/colly v1.2.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,365 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package decode
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxDepth is the maximum decoding depth allowed.
+	MaxDepth = 5
+	// maxBlobLength is the maximum length of an encoded blob.
+	maxBlobLength = 64 * 1024
+	// maxDecodedLength is the maximum length of a decoded (and
+	// decompressed) blob, it avoids decompression bombs.
+	maxDecodedLength = 1024 * 1024
+	// minPrintableRatio is the minimum ratio of printable characters
+	// a decoded blob should have to be considered text.
+	minPrintableRatio = 0.9
+	// jwtParts is the number of dot separated parts of a JWT.
+	jwtParts = 3
+	// maxPreviewLength is the maximum length of a decoded preview.
+	maxPreviewLength = 256
+)
+
+// Encodings.
+const (
+	Base64    = "base64"
+	Base64URL = "base64url"
+	Hex       = "hex"
+	URL       = "url"
+	Gzip      = "gzip"
+)
+
+// Serialization formats.
+const (
+	PHPSerialized    = "PHP serialized"
+	JavaSerialized   = "Java serialized"
+	DotNetSerialized = ".NET serialized"
+	PythonPickle     = "Python pickle"
+	RubyMarshal      = "Ruby Marshal"
+	JSONObject       = "JSON"
+)
+
+var (
+	ErrJWTFormat = errors.New("token is not a well formatted JWT")
+
+	// nolint: gochecknoglobals
+	dataImageRegex = regexp.MustCompile(`data:image/[a-zA-Z0-9.+-]+;base64,[A-Za-z0-9+/=]+`)
+	// nolint: gochecknoglobals
+	jwtRegex = regexp.MustCompile(`eyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`)
+	// nolint: gochecknoglobals
+	base64Regex = regexp.MustCompile(`[A-Za-z0-9+/_-]{20,}={0,2}`)
+	// nolint: gochecknoglobals
+	hexRegex = regexp.MustCompile(`\b(?:[0-9a-fA-F]{2}){10,}\b`)
+	// nolint: gochecknoglobals
+	percentRegex = regexp.MustCompile(`[^\s"'<>&=]*%[0-9A-Fa-f]{2}[^\s"'<>&=]*`)
+	// nolint: gochecknoglobals
+	phpSerializedRegex = regexp.MustCompile(`^(?:a:\d+:\{|O:\d+:"|s:\d+:"|C:\d+:")`)
+)
+
+// Blob struct.
+// Encoding = the encoding (chain) of the blob, e.g. base64>gzip.
+// Encoded = the encoded string found.
+// Decoded = the decoded content.
+// Depth = the decoding depth at which the blob has been found.
+type Blob struct {
+	Encoding string
+	Encoded  string
+	Decoded  string
+	Depth    int
+}
+
+// FindBlobs finds the encoded blobs (base64, base64url, hex and
+// percent-encoded strings, gzip compressed content included) in the
+// input and decodes them recursively up to depth.
+// Base64 images (data:image) are skipped.
+// Only blobs decoded as text are returned.
+func FindBlobs(input string, depth int) []Blob {
+	result := []Blob{}
+	seen := make(map[string]bool)
+
+	findBlobs(input, 1, depth, seen, &result)
+
+	return result
+}
+
+func findBlobs(input string, level, depth int, seen map[string]bool, result *[]Blob) {
+	if level > depth {
+		return
+	}
+
+	input = dataImageRegex.ReplaceAllString(input, "")
+
+	candidates := []struct {
+		regex  *regexp.Regexp
+		decode func(string) (string, string, bool)
+	}{
+		{base64Regex, decodeBase64},
+		{hexRegex, decodeHex},
+		{percentRegex, decodePercent},
+	}
+
+	for _, candidate := range candidates {
+		for _, encoded := range candidate.regex.FindAllString(input, -1) {
+			if len(encoded) > maxBlobLength || seen[encoded] {
+				continue
+			}
+
+			encoding, decoded, ok := candidate.decode(encoded)
+			if !ok || decoded == encoded {
+				continue
+			}
+
+			seen[encoded] = true
+
+			*result = append(*result, Blob{
+				Encoding: encoding,
+				Encoded:  encoded,
+				Decoded:  decoded,
+				Depth:    level,
+			})
+
+			findBlobs(decoded, level+1, depth, seen, result)
+		}
+	}
+}
+
+// decodeBase64 decodes a standard or URL-safe base64 string
+// (padded or not). It returns the encoding, the decoded text
+// and true if the result is text.
+func decodeBase64(input string) (string, string, bool) {
+	encoding, encoders := Base64, []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding}
+	if strings.ContainsAny(input, "-_") {
+		encoding, encoders = Base64URL, []*base64.Encoding{base64.URLEncoding, base64.RawURLEncoding}
+	}
+
+	for _, encoder := range encoders {
+		decoded, err := encoder.DecodeString(input)
+		if err != nil {
+			continue
+		}
+
+		return decodeBytes(encoding, decoded)
+	}
+
+	return "", "", false
+}
+
+// DecodeBase64 decodes a standard or URL-safe base64 string
+// (padded or not). It returns the decoded text and true if
+// the result is text.
+func DecodeBase64(input string) (string, bool) {
+	_, decoded, ok := decodeBase64(input)
+	return decoded, ok
+}
+
+// decodeHex decodes a hex string.
+func decodeHex(input string) (string, string, bool) {
+	decoded, err := hex.DecodeString(input)
+	if err != nil {
+		return "", "", false
+	}
+
+	return decodeBytes(Hex, decoded)
+}
+
+// decodePercent decodes a percent-encoded string.
+func decodePercent(input string) (string, string, bool) {
+	decoded, err := url.QueryUnescape(input)
+	if err != nil || !IsText(decoded) {
+		return "", "", false
+	}
+
+	return URL, decoded, true
+}
+
+// decodeBytes checks if the decoded bytes are text, if they are
+// gzip compressed it decompresses them before checking.
+func decodeBytes(encoding string, decoded []byte) (string, string, bool) {
+	if IsGzip(decoded) {
+		decompressed, err := Gunzip(decoded)
+		if err != nil {
+			return "", "", false
+		}
+
+		encoding += ">" + Gzip
+		decoded = decompressed
+	}
+
+	if !IsText(string(decoded)) {
+		return "", "", false
+	}
+
+	return encoding, string(decoded), true
+}
+
+// IsGzip checks if the input starts with the gzip magic bytes.
+func IsGzip(input []byte) bool {
+	return len(input) > 2 && input[0] == 0x1f && input[1] == 0x8b
+}
+
+// Gunzip decompresses gzip compressed content, reading
+// at most maxDecodedLength bytes.
+func Gunzip(input []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(io.LimitReader(reader, maxDecodedLength))
+}
+
+// IsText checks if the input is valid UTF-8 made (almost)
+// only of printable characters.
+func IsText(input string) bool {
+	if input == "" || !utf8.ValidString(input) {
+		return false
+	}
+
+	printable := 0
+	total := 0
+
+	for _, r := range input {
+		total++
+
+		if unicode.IsPrint(r) || unicode.IsSpace(r) {
+			printable++
+		}
+	}
+
+	return float64(printable)/float64(total) >= minPrintableRatio
+}
+
+// FindJWTs returns all the JSON Web Tokens found in the input.
+func FindJWTs(input string) []string {
+	return jwtRegex.FindAllString(input, -1)
+}
+
+// ParseJWT decodes the header and the claims of a JSON Web Token.
+// The signature is not verified.
+func ParseJWT(token string) (map[string]interface{}, map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != jwtParts {
+		return nil, nil, ErrJWTFormat
+	}
+
+	header := make(map[string]interface{})
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, nil, err
+	}
+
+	claims := make(map[string]interface{})
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, nil, err
+	}
+
+	return header, claims, nil
+}
+
+// decodeJWTPart decodes a base64url JSON part of a JWT.
+func decodeJWTPart(part string, v interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(decoded, v)
+}
+
+// Serialized checks if the input (plain, percent-encoded or base64
+// encoded) is a serialized object.
+// It returns the serialization format, a preview of the
+// decoded content and true if the input is a serialized object.
+func Serialized(input string) (string, string, bool) {
+	if unescaped, err := url.QueryUnescape(input); err == nil {
+		input = unescaped
+	}
+
+	raw := []byte(input)
+
+	for _, encoder := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding,
+		base64.URLEncoding, base64.RawURLEncoding} {
+		if decoded, err := encoder.DecodeString(input); err == nil {
+			raw = decoded
+			break
+		}
+	}
+
+	format := ""
+
+	switch {
+	case phpSerializedRegex.Match(raw):
+		format = PHPSerialized
+	case bytes.HasPrefix(raw, []byte{0xac, 0xed, 0x00, 0x05}):
+		format = JavaSerialized
+	case bytes.HasPrefix(raw, []byte{0x00, 0x01, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xff}),
+		bytes.HasPrefix(raw, []byte{0xff, 0x01}):
+		format = DotNetSerialized
+	case len(raw) > 1 && raw[0] == 0x80 && raw[1] >= 0x02 && raw[1] <= 0x05:
+		format = PythonPickle
+	case bytes.HasPrefix(raw, []byte{0x04, 0x08}):
+		format = RubyMarshal
+	case (bytes.HasPrefix(raw, []byte("{")) || bytes.HasPrefix(raw, []byte("["))) && json.Valid(raw):
+		format = JSONObject
+	default:
+		return "", "", false
+	}
+
+	return format, preview(raw), true
+}
+
+// preview returns the printable characters of the input,
+// truncated to maxPreviewLength.
+func preview(input []byte) string {
+	result := strings.Map(func(r rune) rune {
+		if unicode.IsPrint(r) {
+			return r
+		}
+
+		return '.'
+	}, string(input))
+
+	if runes := []rune(result); len(runes) > maxPreviewLength {
+		result = string(runes[:maxPreviewLength]) + "..."
+	}
+
+	return result
+}
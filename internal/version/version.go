@@ -0,0 +1,107 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package version
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// nolint: gochecknoglobals
+var separatorRegex = regexp.MustCompile(`[.\-]`)
+
+// Compare compares two versions (e.g. 1.12.4, 3.0.0-beta1).
+// It returns -1 if a < b, 0 if a == b and 1 if a > b.
+// The versions are split on dots and dashes, numeric parts are
+// compared as numbers and they are greater than non numeric
+// parts (so 3.0.0 > 3.0.0-rc1). Missing parts count as 0.
+func Compare(a, b string) int {
+	partsA := separatorRegex.Split(a, -1)
+	partsB := separatorRegex.Split(b, -1)
+
+	length := len(partsA)
+	if len(partsB) > length {
+		length = len(partsB)
+	}
+
+	for i := 0; i < length; i++ {
+		numA, isNumA, strA := part(partsA, i)
+		numB, isNumB, strB := part(partsB, i)
+
+		switch {
+		case isNumA != isNumB:
+			if isNumA {
+				return 1
+			}
+
+			return -1
+		case isNumA && numA != numB:
+			if numA > numB {
+				return 1
+			}
+
+			return -1
+		case !isNumA && strA != strB:
+			if strA > strB {
+				return 1
+			}
+
+			return -1
+		}
+	}
+
+	return 0
+}
+
+// InRange checks if version is at or above atOrAbove (if not empty)
+// and below below (if not empty).
+func InRange(version, atOrAbove, below string) bool {
+	if atOrAbove != "" && Compare(version, atOrAbove) < 0 {
+		return false
+	}
+
+	if below != "" && Compare(version, below) >= 0 {
+		return false
+	}
+
+	return true
+}
+
+// part returns the i-th part of a version, as a number
+// if it's numeric. Missing parts count as 0.
+func part(parts []string, i int) (int, bool, string) {
+	if i >= len(parts) || parts[i] == "" {
+		return 0, true, ""
+	}
+
+	num, err := strconv.Atoi(parts[i])
+	if err != nil {
+		return 0, false, parts[i]
+	}
+
+	return num, true, parts[i]
+}
@@ -0,0 +1,132 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package version_test
+
+import (
+	"testing"
+
+	versionUtils "github.com/edoardottt/cariddi/internal/version"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{
+			name: "equal",
+			a:    "1.12.4",
+			b:    "1.12.4",
+			want: 0,
+		},
+		{
+			name: "missing parts",
+			a:    "3.0",
+			b:    "3.0.0",
+			want: 0,
+		},
+		{
+			name: "numeric comparison",
+			a:    "1.9.1",
+			b:    "1.12.0",
+			want: -1,
+		},
+		{
+			name: "major version",
+			a:    "4.0.0",
+			b:    "3.7.7",
+			want: 1,
+		},
+		{
+			name: "release above pre-release",
+			a:    "3.0.0",
+			b:    "3.0.0-beta1",
+			want: 1,
+		},
+		{
+			name: "pre-releases",
+			a:    "3.0.0-alpha.0",
+			b:    "3.0.0-beta1",
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionUtils.Compare(tt.a, tt.b); got != tt.want {
+				t.Errorf("Compare() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		atOrAbove string
+		below     string
+		want      bool
+	}{
+		{
+			name:      "below only",
+			version:   "1.6.2",
+			atOrAbove: "",
+			below:     "1.6.3",
+			want:      true,
+		},
+		{
+			name:      "fixed version",
+			version:   "1.6.3",
+			atOrAbove: "",
+			below:     "1.6.3",
+			want:      false,
+		},
+		{
+			name:      "lower bound included",
+			version:   "1.12.3",
+			atOrAbove: "1.12.3",
+			below:     "3.0.0-beta1",
+			want:      true,
+		},
+		{
+			name:      "below lower bound",
+			version:   "1.12.2",
+			atOrAbove: "1.12.3",
+			below:     "3.0.0-beta1",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionUtils.InRange(tt.version, tt.atOrAbove, tt.below); got != tt.want {
+				t.Errorf("InRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,473 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+	"github.com/gocolly/colly"
+	"gopkg.in/yaml.v3"
+)
+
+// nolint: gochecknoglobals
+var (
+	specPathRegex    = regexp.MustCompile(`(?i)(swagger|openapi|api-docs)[^/]*(\.json|\.ya?ml)?$`)
+	graphqlPathRegex = regexp.MustCompile(`(?i)/graph(i)?ql(/|$)`)
+)
+
+// ErrAPISpecFormat is returned when a document is not an
+// OpenAPI/Swagger specification.
+var ErrAPISpecFormat = errors.New("not an OpenAPI/Swagger specification")
+
+// ErrGraphQLSchema is returned when a GraphQL endpoint
+// doesn't return an introspection result.
+var ErrGraphQLSchema = errors.New("no GraphQL schema in the response")
+
+// apiMethods are the HTTP methods of an OpenAPI path item.
+func apiMethods() []string {
+	return []string{"get", "post", "put", "patch", "delete", "head", "options"}
+}
+
+// apiSpecCandidate checks if a response may be an
+// OpenAPI/Swagger specification.
+func apiSpecCandidate(target string, body []byte) bool {
+	path, _ := urlUtils.GetPath(target)
+
+	return specPathRegex.MatchString(path) || bytes.Contains(body, []byte(`"openapi"`)) ||
+		bytes.Contains(body, []byte(`"swagger"`))
+}
+
+// graphqlCandidate checks if an endpoint looks like a GraphQL endpoint.
+func graphqlCandidate(target string) bool {
+	path, _ := urlUtils.GetPath(target)
+	return graphqlPathRegex.MatchString(path)
+}
+
+// huntAPISpec parses an OpenAPI/Swagger specification
+// and returns the documented endpoints.
+func huntAPISpec(target string, body []byte) []scanner.APIMatched {
+	apis := []scanner.APIMatched{}
+
+	endpoints, err := ParseAPISpec(body, target)
+	if err != nil {
+		return apis
+	}
+
+	for _, endpoint := range endpoints {
+		apis = append(apis, scanner.APIMatched{API: endpoint, URL: target})
+	}
+
+	return apis
+}
+
+// huntGraphQL sends an introspection query to a GraphQL endpoint
+// and returns its queries and mutations.
+func huntGraphQL(client *activeClient, target string) []scanner.APIMatched {
+	apis := []scanner.APIMatched{}
+
+	query, err := json.Marshal(map[string]string{"query": scanner.GetGraphQLIntrospectionQuery()})
+	if err != nil {
+		return apis
+	}
+
+	_, body, err := client.DoBody(http.MethodPost, target, query, map[string]string{"Content-Type": "application/json"})
+	if err != nil {
+		return apis
+	}
+
+	endpoints, err := ParseGraphQLSchema(body)
+	if err != nil {
+		return apis
+	}
+
+	for _, endpoint := range endpoints {
+		apis = append(apis, scanner.APIMatched{API: endpoint, URL: target})
+	}
+
+	return apis
+}
+
+// ParseAPISpec parses an OpenAPI 3 or Swagger 2 specification (JSON or YAML)
+// and returns the documented endpoints. The URLs are built with example
+// values for the path and query parameters, relative server URLs
+// are resolved against base.
+func ParseAPISpec(data []byte, base string) ([]scanner.APIEndpoint, error) {
+	spec := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAPISpecFormat, err.Error())
+	}
+
+	source := scanner.APISourceOpenAPI
+	if _, ok := spec["openapi"]; !ok {
+		if _, ok := spec["swagger"]; !ok {
+			return nil, ErrAPISpecFormat
+		}
+
+		source = scanner.APISourceSwagger
+	}
+
+	paths, ok := spec["paths"].(map[string]interface{})
+	if !ok {
+		return nil, ErrAPISpecFormat
+	}
+
+	server := apiServer(spec, base)
+	names := make([]string, 0, len(paths))
+
+	for name := range paths {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	endpoints := []scanner.APIEndpoint{}
+
+	for _, path := range names {
+		item, ok := resolveRef(spec, paths[path]).(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, method := range apiMethods() {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			endpoint := scanner.APIEndpoint{Source: source, Method: strings.ToUpper(method), Path: path}
+			endpoint.Operation = apiString(operation["operationId"])
+
+			if endpoint.Operation == "" {
+				endpoint.Operation = apiString(operation["summary"])
+			}
+
+			parameters := append(apiList(item["parameters"]), apiList(operation["parameters"])...)
+			endpoint.URL, endpoint.Parameters = apiURL(spec, server, path, parameters)
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// apiServer returns the base URL of the API described by a specification.
+func apiServer(spec map[string]interface{}, base string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		baseURL = &url.URL{}
+	}
+
+	server := ""
+
+	if servers := apiList(spec["servers"]); len(servers) != 0 {
+		// OpenAPI 3: servers[0].url with the default values of the variables.
+		first, _ := servers[0].(map[string]interface{})
+		server = apiString(first["url"])
+
+		variables, _ := first["variables"].(map[string]interface{})
+		for name, variable := range variables {
+			if values, ok := variable.(map[string]interface{}); ok {
+				server = strings.ReplaceAll(server, "{"+name+"}", apiString(values["default"]))
+			}
+		}
+	} else if host := apiString(spec["host"]); host != "" || spec["basePath"] != nil {
+		// Swagger 2: schemes, host and basePath.
+		scheme := baseURL.Scheme
+		if schemes := apiList(spec["schemes"]); len(schemes) != 0 {
+			scheme = apiString(schemes[0])
+		}
+
+		if host == "" {
+			host = baseURL.Host
+		}
+
+		server = scheme + "://" + host + apiString(spec["basePath"])
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return strings.TrimSuffix(server, "/")
+	}
+
+	if server == "" {
+		serverURL = &url.URL{Path: "/"}
+	}
+
+	return strings.TrimSuffix(baseURL.ResolveReference(serverURL).String(), "/")
+}
+
+// apiURL builds the URL of an endpoint with example values
+// and returns it along with the names of the parameters.
+func apiURL(spec map[string]interface{}, server, path string, parameters []interface{}) (string, []string) {
+	var names []string
+
+	query := url.Values{}
+	queryNames := []string{}
+
+	for _, elem := range parameters {
+		parameter, ok := resolveRef(spec, elem).(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name := apiString(parameter["name"])
+		if name == "" {
+			continue
+		}
+
+		names = append(names, name)
+		value := apiExample(spec, parameter)
+
+		switch apiString(parameter["in"]) {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+		case "query":
+			if _, ok := query[name]; !ok {
+				queryNames = append(queryNames, name)
+			}
+
+			query.Set(name, value)
+		}
+	}
+
+	result := server + path
+
+	// Keep the documented order of the query parameters.
+	values := []string{}
+	for _, name := range queryNames {
+		values = append(values, url.QueryEscape(name)+"="+url.QueryEscape(query.Get(name)))
+	}
+
+	if len(values) != 0 {
+		result += "?" + strings.Join(values, "&")
+	}
+
+	return result, names
+}
+
+// apiExample returns an example value for a parameter:
+// the example, the default or the first enum value
+// (of the parameter or of its schema), otherwise a value
+// depending on the type.
+func apiExample(spec map[string]interface{}, parameter map[string]interface{}) string {
+	schema, ok := resolveRef(spec, parameter["schema"]).(map[string]interface{})
+	if !ok {
+		// Swagger 2 non-body parameters define the type in the parameter itself.
+		schema = parameter
+	}
+
+	for _, source := range []map[string]interface{}{parameter, schema} {
+		for _, key := range []string{"example", "default"} {
+			if value, ok := source[key]; ok && value != nil {
+				return apiString(value)
+			}
+		}
+
+		if enum := apiList(source["enum"]); len(enum) != 0 {
+			return apiString(enum[0])
+		}
+	}
+
+	switch apiString(schema["type"]) {
+	case "integer", "number":
+		return "1"
+	case "boolean":
+		return "true"
+	}
+
+	return "test"
+}
+
+// resolveRef resolves a local reference ($ref: '#/...').
+func resolveRef(spec map[string]interface{}, elem interface{}) interface{} {
+	object, ok := elem.(map[string]interface{})
+	if !ok {
+		return elem
+	}
+
+	ref := apiString(object["$ref"])
+	if !strings.HasPrefix(ref, "#/") {
+		return elem
+	}
+
+	var current interface{} = spec
+
+	for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		key = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+
+		parent, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		current = parent[key]
+	}
+
+	return current
+}
+
+// apiString returns the string representation of a value.
+func apiString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(value)
+}
+
+// apiList returns a value as a list.
+func apiList(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+
+	return []interface{}{}
+}
+
+// graphqlSchema is the result of the introspection query.
+type graphqlSchema struct {
+	Data struct {
+		Schema struct {
+			QueryType    *struct{ Name string } `json:"queryType"`
+			MutationType *struct{ Name string } `json:"mutationType"`
+			Types        []struct {
+				Name   string
+				Fields []struct {
+					Name string
+					Args []struct{ Name string }
+				}
+			} `json:"types"`
+		} `json:"__schema"`
+	} `json:"data"`
+}
+
+// ParseGraphQLSchema parses the result of the introspection
+// query and returns the queries and the mutations.
+func ParseGraphQLSchema(data []byte) ([]scanner.APIEndpoint, error) {
+	result := graphqlSchema{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	schema := result.Data.Schema
+	if schema.QueryType == nil && schema.MutationType == nil {
+		return nil, ErrGraphQLSchema
+	}
+
+	operations := map[string]string{}
+	if schema.QueryType != nil {
+		operations[schema.QueryType.Name] = scanner.GraphQLQuery
+	}
+
+	if schema.MutationType != nil {
+		operations[schema.MutationType.Name] = scanner.GraphQLMutation
+	}
+
+	endpoints := []scanner.APIEndpoint{}
+
+	for _, graphqlType := range schema.Types {
+		operation, ok := operations[graphqlType.Name]
+		if !ok {
+			continue
+		}
+
+		for _, field := range graphqlType.Fields {
+			endpoint := scanner.APIEndpoint{Source: scanner.APISourceGraphQL, Method: operation, Path: field.Name}
+			for _, arg := range field.Args {
+				endpoint.Parameters = append(endpoint.Parameters, arg.Name)
+			}
+
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// seedAPI reports the API endpoints found and uses them as crawl seeds:
+// the GET endpoints are visited and the URLs are checked
+// for juicy parameters (if the endpoints scan is enabled).
+func seedAPI(apis []scanner.APIMatched, scan *Scan, event *Event, c *colly.Collector, matches *scanner.Results) {
+	for _, elem := range apis {
+		matches.APIs = append(matches.APIs, elem)
+
+		if elem.API.URL == "" {
+			continue
+		}
+
+		if scan.EndpointsFlag {
+			for _, endpoint := range huntEndpoints(elem.API.URL, "", &scan.EndpointsSlice, &scan.PathsSlice) {
+				if len(endpoint.Parameters) != 0 || len(endpoint.Paths) != 0 {
+					matches.Endpoints = append(matches.Endpoints, endpoint)
+				}
+			}
+		}
+
+		if elem.API.Method == http.MethodGet {
+			visitLink(event, c, elem.API.URL)
+		}
+	}
+}
+
+// loadAPISpec reads a local OpenAPI/Swagger specification and returns
+// the documented endpoints. Relative server URLs are resolved against the target.
+// If the file can't be read or parsed it exits.
+func loadAPISpec(filename, base string) []scanner.APIMatched {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	endpoints, err := ParseAPISpec(data, base)
+	if err != nil {
+		fmt.Println("The API specification " + filename + " is not valid: " + err.Error())
+		os.Exit(1)
+	}
+
+	apis := []scanner.APIMatched{}
+	for _, endpoint := range endpoints {
+		apis = append(apis, scanner.APIMatched{API: endpoint, URL: filename})
+	}
+
+	return apis
+}
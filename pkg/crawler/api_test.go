@@ -0,0 +1,265 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestParseAPISpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		base    string
+		want    []scanner.APIEndpoint
+		wantErr bool
+	}{
+		{
+			name: "openapi 3 yaml",
+			spec: `openapi: 3.0.0
+servers:
+  - url: /{version}
+    variables:
+      version:
+        default: v1
+paths:
+  /users/{id}:
+    parameters:
+      - $ref: '#/components/parameters/id'
+    get:
+      operationId: getUser
+      parameters:
+        - name: fields
+          in: query
+          schema:
+            type: string
+            enum: [name, email]
+    delete:
+      summary: Delete a user
+  /search:
+    post:
+      operationId: search
+      parameters:
+        - name: q
+          in: query
+          example: admin
+        - name: debug
+          in: query
+          schema:
+            type: boolean
+components:
+  parameters:
+    id:
+      name: id
+      in: path
+      schema:
+        type: integer
+`,
+			base: "https://example.com/docs/openapi.yaml",
+			want: []scanner.APIEndpoint{
+				{
+					Source: "openapi", Method: "POST", Path: "/search", URL: "https://example.com/v1/search?q=admin&debug=true",
+					Operation: "search", Parameters: []string{"q", "debug"},
+				},
+				{
+					Source: "openapi", Method: "GET", Path: "/users/{id}", URL: "https://example.com/v1/users/1?fields=name",
+					Operation: "getUser", Parameters: []string{"id", "fields"},
+				},
+				{
+					Source: "openapi", Method: "DELETE", Path: "/users/{id}", URL: "https://example.com/v1/users/1",
+					Operation: "Delete a user", Parameters: []string{"id"},
+				},
+			},
+		},
+		{
+			name: "swagger 2 json",
+			spec: `{"swagger": "2.0", "host": "api.example.com", "basePath": "/api", "schemes": ["https"],
+				"paths": {"/login": {"post": {"operationId": "login", "parameters": [
+					{"name": "redirect", "in": "query", "type": "string", "default": "/home"},
+					{"name": "body", "in": "body", "schema": {"type": "object"}}]}}}}`,
+			base: "http://example.com/swagger.json",
+			want: []scanner.APIEndpoint{
+				{
+					Source: "swagger", Method: "POST", Path: "/login", URL: "https://api.example.com/api/login?redirect=%2Fhome",
+					Operation: "login", Parameters: []string{"redirect", "body"},
+				},
+			},
+		},
+		{
+			name: "swagger 2 without host",
+			spec: `{"swagger": "2.0", "basePath": "/v2", "paths": {"/pets": {"get": {}}}}`,
+			base: "http://example.com/swagger.json",
+			want: []scanner.APIEndpoint{
+				{Source: "swagger", Method: "GET", Path: "/pets", URL: "http://example.com/v2/pets"},
+			},
+		},
+		{
+			name:    "not a specification",
+			spec:    `{"name": "package.json", "version": "1.0.0"}`,
+			base:    "http://example.com/package.json",
+			wantErr: true,
+		},
+		{
+			name:    "not a document",
+			spec:    `<html><body>swagger</body></html>`,
+			base:    "http://example.com/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := crawler.ParseAPISpec([]byte(tt.spec), tt.base)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAPISpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAPISpec() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGraphQLSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []scanner.APIEndpoint
+		wantErr bool
+	}{
+		{
+			name: "queries and mutations",
+			data: `{"data": {"__schema": {"queryType": {"name": "Query"}, "mutationType": {"name": "Mutation"},
+				"types": [
+					{"name": "Query", "fields": [{"name": "user", "args": [{"name": "id"}]}, {"name": "me", "args": []}]},
+					{"name": "Mutation", "fields": [{"name": "deleteUser", "args": [{"name": "id"}]}]},
+					{"name": "User", "fields": [{"name": "email", "args": []}]}]}}}`,
+			want: []scanner.APIEndpoint{
+				{Source: "graphql", Method: "query", Path: "user", Parameters: []string{"id"}},
+				{Source: "graphql", Method: "query", Path: "me"},
+				{Source: "graphql", Method: "mutation", Path: "deleteUser", Parameters: []string{"id"}},
+			},
+		},
+		{
+			name:    "introspection disabled",
+			data:    `{"errors": [{"message": "GraphQL introspection is not allowed"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			data:    `<html></html>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := crawler.ParseGraphQLSchema([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGraphQLSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseGraphQLSchema() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPISpecSeeds(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><a href="/about">about</a></html>`))
+	})
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><a href="/users/export">export</a></html>`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	spec := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(spec, []byte(`openapi: 3.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+  /admin:
+    delete:
+      operationId: deleteAdmin
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	results := crawler.New(&crawler.Scan{
+		Target:      server.URL,
+		Concurrency: 1,
+		Timeout:     5,
+		JSON:        true,
+		APIFlag:     true,
+		SpecFile:    spec,
+	})
+
+	gotAPIs := []string{}
+	for _, elem := range results.APIs {
+		gotAPIs = append(gotAPIs, elem.API.Method+" "+elem.API.Path)
+	}
+
+	sort.Strings(gotAPIs)
+
+	wantAPIs := []string{"DELETE /admin", "GET /users"}
+	if !reflect.DeepEqual(gotAPIs, wantAPIs) {
+		t.Errorf("APIs\n%v", gotAPIs)
+		t.Errorf("want\n%v", wantAPIs)
+	}
+
+	// The GET endpoints are visited and crawled.
+	gotURLs := []string{}
+	for _, url := range results.URLs {
+		gotURLs = append(gotURLs, url[len(server.URL):])
+	}
+
+	sort.Strings(gotURLs)
+
+	wantURLs := []string{"/about", "/users", "/users/export"}
+	if !reflect.DeepEqual(gotURLs, wantURLs) {
+		t.Errorf("URLs\n%v", gotURLs)
+		t.Errorf("want\n%v", wantURLs)
+	}
+}
@@ -0,0 +1,285 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+	"github.com/gocolly/colly"
+)
+
+const (
+	maxArchiveDepth   = 3
+	maxArchiveEntries = 1000
+	maxArchiveSize    = 50 << 20
+)
+
+// archiveLister lists the files of an archive and of the archives
+// nested in it, sharing the limits between them.
+// size = the number of bytes decompressed (read) so far.
+type archiveLister struct {
+	entries []scanner.ArchiveEntry
+	size    int
+}
+
+// huntArchive lists in memory the files of an archive (ZIP, JAR, TAR,
+// GZIP, BZIP2) and hunts for juicy files, secrets and infos in them.
+// The findings point at archive-url!/inner/path.
+func huntArchive(r *colly.Response, scan *Scan, matches *scanner.Results) {
+	secrets := []scanner.SecretMatched{}
+	infos := []scanner.InfoMatched{}
+
+	for _, entry := range ListArchive(r.Request.URL.String(), r.Body) {
+		if entry.Content == nil {
+			continue
+		}
+
+		if 1 <= scan.FileType && scan.FileType <= 7 {
+			extension := huntExtensions(entry.Path, nil, entry.Content, scan.FileType)
+			if extension.URL != "" {
+				matches.Extensions = append(matches.Extensions, extension)
+			}
+		}
+
+		text := ""
+
+		if document, ok := ExtractDocument(entry.Content); ok {
+			if scan.InfoFlag {
+				infos = append(infos, MetadataMatch(entry.Path, document)...)
+			}
+
+			text = document.Text
+		} else if isText(entry.Content) {
+			text = string(entry.Content)
+		}
+
+		if text == "" {
+			continue
+		}
+
+		if scan.SecretsFlag {
+			secrets = append(secrets, withoutSecretRegions(huntSecrets(entry.Path, text, &scan.SecretsSlice))...)
+		}
+
+		if scan.InfoFlag {
+			infos = append(infos, withoutInfoRegions(huntInfos(entry.Path, text))...)
+		}
+	}
+
+	// The matches found in the files win over the same
+	// matches found in the (stored) archive itself.
+	matches.Secrets = scanner.RemoveDuplicateSecrets(append(secrets, matches.Secrets...))
+	matches.Infos = scanner.RemoveDuplicateInfos(append(infos, matches.Infos...))
+}
+
+// ListArchive lists in memory the files of an archive and of the
+// archives nested in it. The number of files, their size and the
+// nesting depth are limited. It returns nil if the body is not an archive.
+func ListArchive(target string, body []byte) []scanner.ArchiveEntry {
+	if !isArchive(body) {
+		return nil
+	}
+
+	lister := &archiveLister{}
+	lister.list(target, body, 1)
+
+	return lister.entries
+}
+
+// isArchive checks if the content is an archive. Office
+// documents (ZIP files too) are not considered archives.
+func isArchive(body []byte) bool {
+	switch sniffExtension(body) {
+	case "zip":
+		_, isDocument := ExtractDocument(body)
+		return !isDocument
+	case "tar", "gz", "bz2":
+		return true
+	}
+
+	return false
+}
+
+// isText checks if the content is text, sniffing it.
+func isText(content []byte) bool {
+	return len(content) != 0 && strings.HasPrefix(http.DetectContentType(content), "text/")
+}
+
+// list lists the files of an archive at the given depth.
+func (l *archiveLister) list(archive string, body []byte, depth int) {
+	switch sniffExtension(body) {
+	case "zip":
+		l.listZip(archive, body, depth)
+	case "tar":
+		l.listTar(archive, body, depth)
+	case "gz":
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		defer reader.Close()
+
+		l.listCompressed(archive, reader, depth)
+	case "bz2":
+		l.listCompressed(archive, bzip2.NewReader(bytes.NewReader(body)), depth)
+	}
+}
+
+// listZip lists the files of a ZIP archive.
+func (l *archiveLister) listZip(archive string, body []byte, depth int) {
+	reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return
+	}
+
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		var content []byte
+
+		if file.UncompressedSize64 <= maxDocumentEntrySize {
+			if !l.fits(int64(file.UncompressedSize64)) {
+				return
+			}
+
+			content, err = readZipFile(file)
+			if err != nil {
+				content = nil
+			}
+
+			l.size += len(content)
+		}
+
+		if !l.add(scanner.ArchivePath(archive, file.Name), int64(file.UncompressedSize64), content, depth) {
+			return
+		}
+	}
+}
+
+// listTar lists the regular files of a TAR archive.
+func (l *archiveLister) listTar(archive string, body []byte, depth int) {
+	reader := tar.NewReader(bytes.NewReader(body))
+
+	for {
+		header, err := reader.Next()
+		if err != nil {
+			return
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		var content []byte
+
+		if header.Size <= maxDocumentEntrySize {
+			if !l.fits(header.Size) {
+				return
+			}
+
+			content, err = io.ReadAll(io.LimitReader(reader, maxDocumentEntrySize))
+			if err != nil {
+				content = nil
+			}
+
+			l.size += len(content)
+		}
+
+		if !l.add(scanner.ArchivePath(archive, header.Name), header.Size, content, depth) {
+			return
+		}
+	}
+}
+
+// listCompressed decompresses a GZIP or BZIP2 file: a tarball is
+// listed, any other file is listed as the only file of the archive.
+func (l *archiveLister) listCompressed(archive string, reader io.Reader, depth int) {
+	if l.size >= maxArchiveSize {
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, int64(maxArchiveSize-l.size)))
+	if err != nil && len(data) == 0 {
+		return
+	}
+
+	l.size += len(data)
+
+	if sniffExtension(data) == "tar" {
+		l.listTar(archive, data, depth)
+
+		return
+	}
+
+	name := path.Base(filePath(archive))
+	name = strings.TrimSuffix(strings.TrimSuffix(name, path.Ext(name)), ".tar")
+
+	if name == "" || name == "." || name == "/" {
+		name = "data"
+	}
+
+	l.add(scanner.ArchivePath(archive, name), int64(len(data)), data, depth)
+}
+
+// fits checks if a file of the given (uncompressed) size can be read
+// without exceeding the size limit shared by the nested archives.
+// The size is checked before decompressing the file.
+func (l *archiveLister) fits(size int64) bool {
+	return int64(l.size)+size <= maxArchiveSize
+}
+
+// add adds a file to the list, dropping its content if it exceeds the
+// limits, and lists it if it's an archive. It returns false when the
+// maximum number of files has been reached.
+func (l *archiveLister) add(filePath string, size int64, content []byte, depth int) bool {
+	if len(l.entries) >= maxArchiveEntries {
+		return false
+	}
+
+	if len(content) > maxDocumentEntrySize {
+		content = nil
+	}
+
+	l.entries = append(l.entries, scanner.ArchiveEntry{Path: filePath, Size: size, Depth: depth, Content: content})
+
+	if content != nil && depth < maxArchiveDepth && isArchive(content) {
+		l.list(filePath, content, depth+1)
+	}
+
+	return len(l.entries) < maxArchiveEntries
+}
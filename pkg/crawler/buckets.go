@@ -0,0 +1,160 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// huntBuckets hunts for cloud storage buckets.
+func huntBuckets(target string, body string) []scanner.BucketMatched {
+	bucketsSlice := BucketsMatch(target, body)
+	return bucketsSlice
+}
+
+// BucketsMatch finds the cloud storage buckets (S3, GCS, Azure Blob
+// and DigitalOcean Spaces) referenced in the url and in the body
+// of a response and extracts their names.
+func BucketsMatch(target string, body string) []scanner.BucketMatched {
+	buckets := []scanner.BucketMatched{}
+	seen := make(map[string]bool)
+	text := target + "\n" + body
+
+	for _, bucketRegex := range scanner.GetBucketRegexes() {
+		re := regexp.MustCompile(bucketRegex.Regex)
+
+		for _, match := range re.FindAllStringSubmatch(text, -1) {
+			groups := make(map[string]string)
+
+			for i, name := range re.SubexpNames() {
+				if name != "" {
+					groups[name] = strings.ToLower(match[i])
+				}
+			}
+
+			bucket := scanner.Bucket{Provider: bucketRegex.Provider, Name: groups["bucket"], Region: groups["region"]}
+			if account := groups["account"]; account != "" {
+				bucket.Name = account
+				if container := groups["container"]; container != "" {
+					bucket.Name += "/" + container
+				}
+			}
+
+			if seen[bucket.Provider+bucket.Name] {
+				continue
+			}
+
+			seen[bucket.Provider+bucket.Name] = true
+			buckets = append(buckets, scanner.BucketMatched{
+				Bucket: bucket,
+				URL:    target,
+				Match: strings.TrimLeftFunc(match[0], func(r rune) bool {
+					return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+				}),
+			})
+		}
+	}
+
+	return buckets
+}
+
+// checkBucket performs an unauthenticated list request
+// against a bucket and returns the result.
+// If endpoint is not empty, it is used (path-style) instead
+// of the provider endpoint.
+func checkBucket(client *activeClient, endpoint string, bucket scanner.Bucket) string {
+	target := bucketListURL(endpoint, bucket)
+	if target == "" {
+		return ""
+	}
+
+	resp, body, err := client.Do(http.MethodGet, target, nil)
+	if err != nil {
+		return ""
+	}
+
+	content := string(body)
+
+	switch {
+	case resp.StatusCode == http.StatusOK &&
+		(strings.Contains(content, "<ListBucketResult") || strings.Contains(content, "<EnumerationResults")):
+		return scanner.BucketListable
+	case resp.StatusCode == http.StatusNotFound || strings.Contains(content, "NoSuchBucket") ||
+		strings.Contains(content, "ContainerNotFound"):
+		return scanner.BucketNotFound
+	default:
+		return scanner.BucketNotListable
+	}
+}
+
+// bucketListURL returns the URL listing the content of a bucket.
+// Azure storage accounts can't be listed without a container.
+func bucketListURL(endpoint string, bucket scanner.Bucket) string {
+	azureList := "?restype=container&comp=list"
+
+	if bucket.Provider == scanner.BucketAzure && !strings.Contains(bucket.Name, "/") {
+		return ""
+	}
+
+	if endpoint != "" {
+		target := strings.TrimSuffix(endpoint, "/") + "/" + bucket.Name
+		if bucket.Provider == scanner.BucketAzure {
+			target += azureList
+		}
+
+		return target
+	}
+
+	switch bucket.Provider {
+	case scanner.BucketS3:
+		host := "s3.amazonaws.com"
+		if bucket.Region != "" {
+			host = "s3." + bucket.Region + ".amazonaws.com"
+		}
+
+		// Bucket names with dots don't match the wildcard certificate.
+		if strings.Contains(bucket.Name, ".") {
+			return "https://" + host + "/" + bucket.Name
+		}
+
+		return "https://" + bucket.Name + "." + host + "/"
+	case scanner.BucketGCS:
+		return "https://storage.googleapis.com/" + bucket.Name
+	case scanner.BucketAzure:
+		parts := strings.SplitN(bucket.Name, "/", 2)
+		return "https://" + parts[0] + ".blob.core.windows.net/" + parts[1] + azureList
+	case scanner.BucketDO:
+		return "https://" + bucket.Name + "." + bucket.Region + ".digitaloceanspaces.com/"
+	}
+
+	return ""
+}
@@ -0,0 +1,135 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/output"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestBucketsMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		want   []string
+	}{
+		{
+			name:   "no buckets",
+			target: "https://example.com/",
+			body:   `<img src="https://cdn.example.com/logo.png">`,
+			want:   []string{},
+		},
+		{
+			name:   "s3 forms",
+			target: "https://example.com/",
+			body: `"https://assets.example.s3.amazonaws.com/a.png" "https://s3.eu-west-1.amazonaws.com/backups-prod/x"` +
+				` "s3://logs-bucket/2023" "https://media-bucket.s3.us-gov-west-1.amazonaws.com/v.mp4"`,
+			want: []string{
+				"AWS S3 - assets.example",
+				"AWS S3 - media-bucket (us-gov-west-1)",
+				"AWS S3 - backups-prod (eu-west-1)",
+				"AWS S3 - logs-bucket",
+			},
+		},
+		{
+			name:   "gcs, azure and digitalocean",
+			target: "https://example.com/static/app.js",
+			body: `a="https://storage.googleapis.com/my-gcs-bucket/f.js";b="gs://other_bucket";` +
+				`c="https://acct123.blob.core.windows.net/images/logo.png";d="https://space1.nyc3.digitaloceanspaces.com/x"`,
+			want: []string{
+				"Google Cloud Storage - my-gcs-bucket",
+				"Google Cloud Storage - other_bucket",
+				"Azure Blob Storage - acct123/images",
+				"DigitalOcean Spaces - space1 (nyc3)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, elem := range crawler.BucketsMatch(tt.target, tt.body) {
+				got = append(got, output.FormatBucket(elem))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BucketsMatch\n%v", got)
+				t.Errorf("want\n%v", tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketsCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><img src="https://open-bucket.s3.amazonaws.com/a.png">` +
+			`<img src="https://closed-bucket.s3.amazonaws.com/b.png"></html>`))
+	})
+	mux.HandleFunc("/open-bucket", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><ListBucketResult><Name>open-bucket</Name></ListBucketResult>`))
+	})
+	mux.HandleFunc("/closed-bucket", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><Error><Code>AccessDenied</Code></Error>`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	results := crawler.New(&crawler.Scan{
+		Target:        server.URL,
+		Concurrency:   1,
+		Timeout:       5,
+		JSON:          true,
+		CloudFlag:     true,
+		CloudCheck:    true,
+		CloudEndpoint: server.URL,
+	})
+
+	got := []string{}
+	for _, elem := range scanner.RemoveDuplicateBuckets(results.Buckets) {
+		got = append(got, output.FormatBucket(elem))
+	}
+
+	want := []string{
+		"AWS S3 - open-bucket [" + scanner.BucketListable + "]",
+		"AWS S3 - closed-bucket [" + scanner.BucketNotListable + "]",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Buckets\n%v", got)
+		t.Errorf("want\n%v", want)
+	}
+}
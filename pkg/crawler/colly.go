@@ -37,6 +37,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"time"
 
 	fileUtils "github.com/edoardottt/cariddi/internal/file"
@@ -52,12 +53,14 @@ import (
 // New it's the actual crawler engine.
 // It controls all the behaviours of a scan
 // (event handlers, secrets, errors, extensions and endpoints scanning).
-func New(scan *Scan) *Results {
+func New(scan *Scan) *scanner.Results {
 	// This is to avoid to insert into the crawler target regular
 	// expression directories passed as input.
 	var targetTemp, protocolTemp string
 
-	results := &Results{}
+	results := &scanner.Results{}
+	// The callbacks run concurrently (async collector).
+	resultsMutex := &sync.Mutex{}
 
 	// if there isn't a scheme use http.
 	if !urlUtils.HasProtocol(scan.Target) {
@@ -105,6 +108,9 @@ func New(scan *Scan) *Results {
 	c := CreateColly(scan.Delay, scan.Concurrency, scan.Cache, scan.Timeout,
 		scan.Intensive, scan.Rua, scan.Proxy, scan.UserAgent, scan.Target)
 
+	// Client used by the active checks.
+	client := newActiveClient(scan, c.UserAgent)
+
 	event := &Event{
 		ProtocolTemp: protocolTemp,
 		TargetTemp:   targetTemp,
@@ -115,6 +121,53 @@ func New(scan *Scan) *Results {
 		JSON:         scan.JSON,
 		IgnoreSlice:  ignoreSlice,
 		URLs:         &results.URLs,
+		Mutex:        resultsMutex,
+	}
+
+	// Compiled fingerprints database.
+	var technologies *Technologies
+	if scan.TechFlag {
+		technologies = loadTechnologies(scan.TechFile)
+	}
+
+	// Compiled vulnerabilities database.
+	var jsLibraries *JSLibraries
+	if scan.JSLibsFlag {
+		jsLibraries = loadJSLibraries(scan.JSLibsFile)
+	}
+
+	// Endpoints of the local API specification.
+	var specAPIs []scanner.APIMatched
+	if scan.SpecFile != "" {
+		specAPIs = loadAPISpec(scan.SpecFile, protocolTemp+"://"+scan.Target)
+	}
+
+	// Findings reported only once per host.
+	headersSeen := newSeenSet()
+	cookiesSeen := newSeenSet()
+	techSeen := newSeenSet()
+	hostsSeen := newSeenSet()
+	// Certificates retrieved only once per host.
+	certSeen := newSeenSet()
+	// Host used to classify the hostnames harvested.
+	targetHost := urlUtils.RemovePort(urlUtils.GetHost(protocolTemp + "://" + scan.Target))
+	// Active checks performed only once per endpoint.
+	corsSeen := newSeenSet()
+	// Buckets reported (and checked) only once.
+	bucketsSeen := newSeenSet()
+	// GraphQL endpoints introspected only once.
+	graphqlSeen := newSeenSet()
+	// Parameters injected only once per endpoint.
+	reflectionsSeen := newSeenSet()
+	redirectsSeen := newSeenSet()
+	// Version control files probed once per host,
+	// backup copies once per file.
+	vcsSeen := newSeenSet()
+	backupsSeen := newSeenSet()
+	// Controlled URL injected in the redirect-like parameters.
+	redirURL := scan.RedirURL
+	if redirURL == "" {
+		redirURL = scanner.RedirectDefaultURL
 	}
 
 	registerHTMLEvents(c, event)
@@ -129,81 +182,221 @@ func New(scan *Scan) *Results {
 		})
 	}
 
-	c.OnResponse(func(r *colly.Response) {
-		if !scan.JSON {
-			fmt.Println(r.Request.URL)
-		}
-
-		if scan.StoreResp {
-			err := output.StoreHTTPResponse(r)
-			if err != nil {
-				log.Println(err)
-			}
-		}
+	// Keep the URL requested, the redirects are followed by the crawler
+	if scan.RedirFlag {
+		c.OnRequest(func(r *colly.Request) {
+			r.Ctx.Put(requestedURLKey, r.URL.String())
+		})
+	}
 
+	c.OnResponse(func(r *colly.Response) {
 		minBodyLentgh := 10
 		lengthOk := len(string(r.Body)) > minBodyLentgh
-		secrets := []scanner.SecretMatched{}
-		parameters := []scanner.Parameter{}
-		errors := []scanner.ErrorMatched{}
-		infos := []scanner.InfoMatched{}
-		filetype := &scanner.FileType{}
+		matches := &scanner.Results{}
 
 		// if endpoints or secrets or filetype: scan
 		if scan.EndpointsFlag || scan.SecretsFlag ||
-			(1 <= scan.FileType && scan.FileType <= 7) || scan.ErrorsFlag || scan.InfoFlag {
+			(1 <= scan.FileType && scan.FileType <= 7) || scan.ErrorsFlag || scan.InfoFlag ||
+			scan.DecodeDepth > 0 || scan.SecHeaders || scan.CookiesFlag || scan.CORSFlag || scan.TechFlag ||
+			scan.JSLibsFlag || scan.HostsFlag || scan.CloudFlag || scan.APIFlag ||
+			scan.XSSFlag || scan.RedirFlag || scan.BackupFlag {
 			// HERE SCAN FOR SECRETS
 			if scan.SecretsFlag && lengthOk {
-				secretsSlice := huntSecrets(r.Request.URL.String(), string(r.Body), &scan.SecretsSlice)
-				results.Secrets = append(results.Secrets, secretsSlice...)
-				secrets = append(secrets, secretsSlice...)
+				matches.Secrets = huntSecrets(r.Request.URL.String(), string(r.Body), &scan.SecretsSlice)
 			}
 			// HERE SCAN FOR ENDPOINTS
 			if scan.EndpointsFlag {
-				endpointsSlice := huntEndpoints(r.Request.URL.String(), &scan.EndpointsSlice)
+				endpointsSlice := huntEndpoints(r.Request.URL.String(), string(r.Body), &scan.EndpointsSlice,
+					&scan.PathsSlice)
 				for _, elem := range endpointsSlice {
-					if len(elem.Parameters) != 0 {
-						results.Endpoints = append(results.Endpoints, elem)
-						parameters = append(parameters, elem.Parameters...)
+					if len(elem.Parameters) != 0 || len(elem.Paths) != 0 {
+						matches.Endpoints = append(matches.Endpoints, elem)
 					}
 				}
 			}
 			// HERE SCAN FOR EXTENSIONS
 			if 1 <= scan.FileType && scan.FileType <= 7 {
-				extension := huntExtensions(r.Request.URL.String(), scan.FileType)
+				extension := huntExtensions(r.Request.URL.String(), r.Headers, r.Body, scan.FileType)
 				if extension.URL != "" {
-					results.Extensions = append(results.Extensions, extension)
-					filetype = &extension.Filetype
+					matches.Extensions = append(matches.Extensions, extension)
 				}
 			}
 			// HERE SCAN FOR ERRORS
 			if scan.ErrorsFlag {
-				errorsSlice := huntErrors(r.Request.URL.String(), string(r.Body))
-				results.Errors = append(results.Errors, errorsSlice...)
-				errors = append(errors, errorsSlice...)
+				matches.Errors = huntErrors(r.Request.URL.String(), string(r.Body))
 			}
 
 			// HERE SCAN FOR INFOS
 			if scan.InfoFlag {
-				infosSlice := huntInfos(r.Request.URL.String(), string(r.Body))
-				results.Infos = append(results.Infos, infosSlice...)
-				infos = append(infos, infosSlice...)
+				matches.Infos = huntInfos(r.Request.URL.String(), string(r.Body))
+			}
+
+			// HERE SCAN FOR HEADERS
+			if scan.SecHeaders {
+				for _, elem := range huntHeaders(r.Request.URL.String(), r.Headers) {
+					if headersSeen.add(elem.Host + elem.Header.Name + elem.Header.HTTPHeader) {
+						matches.Headers = append(matches.Headers, elem)
+					}
+				}
+			}
+
+			// HERE SCAN FOR COOKIES
+			if scan.CookiesFlag {
+				for _, elem := range huntCookies(r.Request.URL.String(), r.Headers) {
+					if cookiesSeen.add(elem.Host + elem.Cookie.Name) {
+						matches.Cookies = append(matches.Cookies, elem)
+					}
+				}
+			}
+
+			// HERE SCAN FOR CORS MISCONFIGURATIONS
+			if scan.CORSFlag && corsCandidate(r.Request.URL.String(), r.Headers) &&
+				corsSeen.add(r.Request.URL.Scheme+r.Request.URL.Host+r.Request.URL.Path) {
+				matches.CORS = huntCORS(client, r.Request.URL.String())
+			}
+
+			// HERE SCAN FOR TECHNOLOGIES
+			if scan.TechFlag {
+				for _, elem := range huntTechnologies(technologies, r.Request.URL.String(), r.Headers, string(r.Body)) {
+					if techSeen.add(elem.Host + elem.Technology.Name + elem.Technology.Version) {
+						matches.Technologies = append(matches.Technologies, elem)
+					}
+				}
+			}
+
+			// HERE SCAN FOR VULNERABLE JAVASCRIPT LIBRARIES
+			if scan.JSLibsFlag {
+				matches.JSLibraries = huntJSLibraries(jsLibraries, r.Request.URL.String(), r.Headers, string(r.Body))
+			}
+
+			// HERE SCAN FOR HOSTS
+			if scan.HostsFlag {
+				for _, elem := range huntHosts(targetHost, r.Request.URL, r.Headers, string(r.Body), scan, certSeen) {
+					if hostsSeen.add(elem.Host.Name) {
+						matches.Hosts = append(matches.Hosts, elem)
+					}
+				}
+			}
+
+			// HERE SCAN FOR CLOUD BUCKETS
+			if scan.CloudFlag {
+				for _, elem := range huntBuckets(r.Request.URL.String(), string(r.Body)) {
+					if bucketsSeen.add(elem.Bucket.Provider + elem.Bucket.Name) {
+						if scan.CloudCheck {
+							elem.Bucket.Status = checkBucket(client, scan.CloudEndpoint, elem.Bucket)
+						}
+
+						matches.Buckets = append(matches.Buckets, elem)
+					}
+				}
+			}
+
+			// HERE SCAN FOR API SPECIFICATIONS
+			if scan.APIFlag {
+				if apiSpecCandidate(r.Request.URL.String(), r.Body) {
+					seedAPI(huntAPISpec(r.Request.URL.String(), r.Body), scan, event, c, matches)
+				}
+
+				if graphqlCandidate(r.Request.URL.String()) &&
+					graphqlSeen.add(r.Request.URL.Scheme+r.Request.URL.Host+r.Request.URL.Path) {
+					matches.APIs = append(matches.APIs, huntGraphQL(client, r.Request.URL.String())...)
+				}
+			}
+
+			// HERE SCAN FOR XSS REFLECTIONS
+			if scan.XSSFlag && reflectionCandidate(r.Request.URL.String()) &&
+				reflectionsSeen.add(endpointKey(r.Request.URL.String())) {
+				matches.Reflections = huntReflections(client, r.Request.URL.String())
+			}
+
+			// HERE SCAN FOR OPEN REDIRECTS
+			if requested := requestedURL(r); scan.RedirFlag && redirectCandidate(requested) &&
+				redirectsSeen.add(endpointKey(requested)) {
+				matches.Redirects = huntRedirects(client, requested, redirURL)
+			}
+
+			// HERE SCAN FOR EXPOSED FILES (VCS, BACKUPS)
+			if scan.BackupFlag {
+				if exposure, ok := ExposureMatch(r.Request.URL.String(), r.Body); ok {
+					matches.Exposures = append(matches.Exposures,
+						scanner.ExposureMatched{Exposure: exposure, URL: r.Request.URL.String()})
+				}
+
+				matches.Exposures = append(matches.Exposures,
+					huntExposures(client, r.Request.URL.String(), vcsSeen, backupsSeen)...)
+			}
+
+			// HERE SCAN FOR DOCUMENT METADATA
+			if scan.InfoFlag || scan.SecretsFlag {
+				huntDocument(r, scan, matches)
+			}
+
+			// HERE SCAN FOR ARCHIVES
+			if scan.InfoFlag || scan.SecretsFlag || (1 <= scan.FileType && scan.FileType <= 7) {
+				huntArchive(r, scan, matches)
+			}
+
+			// HERE SCAN FOR ENCODED CONTENT
+			if scan.DecodeDepth > 0 {
+				huntDecoded(r, scan, matches)
 			}
 		}
 
-		if scan.JSON {
-			jsonOutput, err := output.GetJSONString(
-				r, secrets, parameters, filetype, errors, infos,
-			)
+		// All the secrets, tokens and cookie values are masked
+		// in the stored responses, even the filtered ones.
+		found := *matches
+
+		// Drop the findings already triaged in the baseline.
+		matches.Suppress(scan.Baseline, time.Now())
+
+		// Keep the findings with at least the minimum severity, the most severe first.
+		matches.Filter(scan.MinSeverity)
+		matches.Sort()
+
+		resultsMutex.Lock()
+		results.Append(matches)
+		resultsMutex.Unlock()
+
+		if scan.StoreResp {
+			var replacer *strings.Replacer
+			if scan.Redact {
+				if scan.CookiesFlag {
+					found.Cookies = exchangedCookies(r)
+				}
+
+				replacer = scanner.SecretsReplacer(&found, scan.RedactHash)
+			}
 
-			if err == nil {
-				fmt.Println(string(jsonOutput))
-			} else {
+			err := output.StoreHTTPResponse(r, replacer)
+			if err != nil {
 				log.Println(err)
 			}
 		}
+
+		if scan.Redact {
+			matches.Secrets = scanner.RedactSecrets(matches.Secrets, scan.RedactHash)
+			matches.JWTs = scanner.RedactJWTs(matches.JWTs, scan.RedactHash)
+			matches.Cookies = scanner.RedactCookies(matches.Cookies, scan.RedactHash)
+		}
+
+		if err := scan.Writers.WriteResponse(r, matches); err != nil {
+			log.Println(err)
+		}
 	})
 
+	// Use the endpoints of the local API specification as seeds.
+	// The seeds visits start the collector, so the results are locked.
+	if len(specAPIs) != 0 {
+		matches := &scanner.Results{}
+		seedAPI(specAPIs, scan, event, c, matches)
+		matches.Suppress(scan.Baseline, time.Now())
+		matches.Filter(scan.MinSeverity)
+
+		resultsMutex.Lock()
+		results.Append(matches)
+		resultsMutex.Unlock()
+	}
+
 	// Start scraping on target
 	path, err := urlUtils.GetPath(protocolTemp + "://" + scan.Target)
 	if err == nil {
@@ -267,10 +460,6 @@ func New(scan *Scan) *Results {
 
 	c.Wait()
 
-	if scan.HTML != "" {
-		output.FooterHTML(scan.HTML)
-	}
-
 	return results
 }
 
@@ -438,7 +627,9 @@ func visitLink(event *Event, c *colly.Collector, absoluteURL string) {
 		if !event.Ignore || (event.Ignore && !IgnoreMatch(absoluteURL, &event.IgnoreSlice)) {
 			err := c.Visit(absoluteURL)
 			if !errors.Is(err, colly.ErrAlreadyVisited) {
+				event.Mutex.Lock()
 				*event.URLs = append(*event.URLs, absoluteURL)
+				event.Mutex.Unlock()
 
 				if err != nil && event.Debug {
 					log.Println(err)
@@ -0,0 +1,165 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	decodeUtils "github.com/edoardottt/cariddi/internal/decode"
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+	"github.com/gocolly/colly"
+)
+
+const (
+	// cookieLongExpiry is the lifetime after which a cookie is
+	// considered long-lived.
+	cookieLongExpiry = 365 * 24 * time.Hour
+)
+
+// huntCookies hunts for weak cookies.
+func huntCookies(target string, headers *http.Header) []scanner.CookieMatched {
+	cookiesSlice := CookiesMatch(target, headers)
+	return cookiesSlice
+}
+
+// CookiesMatch checks the attributes and the values of the
+// cookies set by a response (Set-Cookie headers).
+func CookiesMatch(target string, headers *http.Header) []scanner.CookieMatched {
+	cookies := []scanner.CookieMatched{}
+	host := urlUtils.GetHost(target)
+	https := strings.HasPrefix(strings.ToLower(target), "https://")
+	path, _ := urlUtils.GetPath(target)
+	response := http.Response{Header: http.Header{"Set-Cookie": headers.Values("Set-Cookie")}}
+
+	for _, cookie := range response.Cookies() {
+		result := scanner.Cookie{Name: cookie.Name, Domain: cookie.Domain, Path: cookie.Path}
+		session := isSessionCookie(cookie.Name)
+
+		if !cookie.Secure {
+			result.Issues = append(result.Issues, scanner.CookieNoSecure)
+		}
+
+		if !cookie.HttpOnly {
+			result.Issues = append(result.Issues, scanner.CookieNoHTTPOnly)
+		}
+
+		// A missing (zero value) or empty SameSite attribute.
+		if cookie.SameSite == 0 || cookie.SameSite == http.SameSiteDefaultMode {
+			result.Issues = append(result.Issues, scanner.CookieNoSameSite)
+		}
+
+		if cookie.SameSite == http.SameSiteNoneMode && !cookie.Secure {
+			result.Issues = append(result.Issues, scanner.CookieSameSiteNone)
+		}
+
+		// A Domain attribute shares the cookie with all the subdomains.
+		if cookie.Domain != "" {
+			result.Issues = append(result.Issues, scanner.CookieBroadDomain)
+		}
+
+		// The cookie is set by a page in a subdirectory, but
+		// it's sent to the whole website.
+		if cookie.Path == "/" && strings.Count(strings.TrimSuffix(path, "/"), "/") > 1 {
+			result.Issues = append(result.Issues, scanner.CookieBroadPath)
+		}
+
+		if cookie.MaxAge > 0 && time.Duration(cookie.MaxAge)*time.Second > cookieLongExpiry ||
+			cookie.MaxAge == 0 && !cookie.Expires.IsZero() && time.Until(cookie.Expires) > cookieLongExpiry {
+			result.Issues = append(result.Issues, scanner.CookieLongExpiry)
+		}
+
+		if session && !https {
+			result.Issues = append(result.Issues, scanner.CookieSessionOverHTTP)
+		}
+
+		// Decode JWTs and serialized objects.
+		if tokens := decodeUtils.FindJWTs(cookie.Value); len(tokens) != 0 {
+			if jwt, err := JWTMatch(tokens[0]); err == nil {
+				result.Issues = append(result.Issues, scanner.CookieJWT)
+				result.JWTIssues = jwt.Issues
+
+				if claims, err := json.Marshal(jwt.Claims); err == nil {
+					result.Decoded = string(claims)
+				}
+			}
+		} else if format, decoded, ok := decodeUtils.Serialized(cookie.Value); ok {
+			result.Issues = append(result.Issues, scanner.CookieSerialized)
+			result.Format = format
+			result.Decoded = decoded
+		}
+
+		if len(result.Issues) == 0 {
+			continue
+		}
+
+		cookies = append(cookies, scanner.CookieMatched{Cookie: result, Host: host, URL: target, Match: cookie.Raw})
+	}
+
+	return cookies
+}
+
+// exchangedCookies returns all the cookies set by a response
+// and sent by its request (name=value), even the ones without issues.
+// They are used to mask the cookie values in the stored responses.
+func exchangedCookies(r *colly.Response) []scanner.CookieMatched {
+	cookies := []scanner.CookieMatched{}
+	target := r.Request.URL.String()
+	response := http.Response{Header: http.Header{"Set-Cookie": r.Headers.Values("Set-Cookie")}}
+	request := http.Request{Header: http.Header{"Cookie": r.Request.Headers.Values("Cookie")}}
+
+	for _, cookie := range response.Cookies() {
+		cookies = append(cookies, scanner.CookieMatched{
+			Cookie: scanner.Cookie{Name: cookie.Name}, URL: target, Match: cookie.Raw,
+		})
+	}
+
+	for _, cookie := range request.Cookies() {
+		cookies = append(cookies, scanner.CookieMatched{
+			Cookie: scanner.Cookie{Name: cookie.Name}, URL: target, Match: cookie.String(),
+		})
+	}
+
+	return cookies
+}
+
+// isSessionCookie checks if the cookie name looks like
+// a session cookie.
+func isSessionCookie(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, session := range scanner.GetSessionCookieNames() {
+		if strings.Contains(name, session) {
+			return true
+		}
+	}
+
+	return false
+}
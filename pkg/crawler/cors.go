@@ -0,0 +1,97 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// nolint: gochecknoglobals
+var apiPathRegex = regexp.MustCompile(`(?i)(/api|/v[0-9]+/|/rest/|/graphql|/oauth|/auth|/user|/account|\.json)`)
+
+// corsCandidate checks if an endpoint is worth a CORS check:
+// JSON/API-looking endpoints and endpoints already returning
+// CORS headers.
+func corsCandidate(target string, headers *http.Header) bool {
+	contentType := strings.ToLower(headers.Get("Content-Type"))
+
+	return strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "xml") ||
+		headers.Get("Access-Control-Allow-Origin") != "" ||
+		apiPathRegex.MatchString(target)
+}
+
+// huntCORS replays the request of an endpoint with crafted
+// Origin headers and checks if they are trusted.
+func huntCORS(client *activeClient, target string) []scanner.CORSMatched {
+	corsSlice := CORSMatch(client, target)
+	return corsSlice
+}
+
+// CORSMatch checks if an endpoint reflects crafted origins in the
+// Access-Control-Allow-Origin header.
+// Origins trusted along with credentials keep their severity,
+// otherwise they are reported with a low severity.
+func CORSMatch(client *activeClient, target string) []scanner.CORSMatched {
+	results := []scanner.CORSMatched{}
+	host := urlUtils.RemovePort(urlUtils.GetHost(target))
+	https := strings.HasPrefix(strings.ToLower(target), "https://")
+
+	for _, cors := range scanner.GetCORSOrigins(host) {
+		if cors.Name == scanner.CORSInsecureOrigin && !https {
+			continue
+		}
+
+		resp, _, err := client.Do(http.MethodGet, target, map[string]string{"Origin": cors.Origin})
+		if err != nil {
+			continue
+		}
+
+		allowOrigin := resp.Header.Get("Access-Control-Allow-Origin")
+		allowCredentials := strings.EqualFold(strings.TrimSpace(resp.Header.Get("Access-Control-Allow-Credentials")), "true")
+
+		if allowOrigin != cors.Origin {
+			continue
+		}
+
+		match := "Access-Control-Allow-Origin: " + allowOrigin
+		if allowCredentials {
+			match += ", Access-Control-Allow-Credentials: true"
+		} else {
+			cors.Severity = scanner.SeverityLow
+		}
+
+		results = append(results, scanner.CORSMatched{CORS: cors, URL: target, Match: match})
+	}
+
+	return results
+}
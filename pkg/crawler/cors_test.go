@@ -0,0 +1,169 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestCORS(t *testing.T) {
+	// Requests with a crafted Origin missing the custom header of the scan.
+	var missingHeader int32
+
+	reflectOrigin := func(credentials bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if origin := r.Header.Get("Origin"); origin != "" {
+				if r.Header.Get("X-Scan") != "cariddi" {
+					atomic.AddInt32(&missingHeader, 1)
+				}
+
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+
+				if credentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"user": "cariddi"}`))
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><a href="/api/credentials">a</a><a href="/api/public">b</a>` +
+			`<a href="/api/static">c</a></html>`))
+	})
+	mux.HandleFunc("/api/credentials", reflectOrigin(true))
+	mux.HandleFunc("/api/public", reflectOrigin(false))
+	mux.HandleFunc("/api/static", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "https://trusted.com")
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"user": "cariddi"}`))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	results := crawler.New(&crawler.Scan{
+		Target:      server.URL,
+		Concurrency: 1,
+		Timeout:     5,
+		JSON:        true,
+		CORSFlag:    true,
+		Headers:     map[string]string{"X-Scan": "cariddi"},
+	})
+
+	got := []string{}
+	for _, elem := range scanner.RemoveDuplicateCORS(results.CORS) {
+		got = append(got, elem.URL[len(server.URL):]+" "+elem.CORS.Name+" ("+elem.Severity()+")")
+	}
+
+	sort.Strings(got)
+
+	// Origins trusted with credentials keep their severity,
+	// the ones trusted without credentials are low.
+	want := []string{
+		"/api/credentials Arbitrary origin trusted (high)",
+		"/api/credentials Arbitrary subdomain trusted (medium)",
+		"/api/credentials Insecure scheme origin trusted (medium)",
+		"/api/credentials Origin prefix match (high)",
+		"/api/credentials Origin suffix match (high)",
+		"/api/credentials null origin trusted (high)",
+		"/api/public Arbitrary origin trusted (low)",
+		"/api/public Arbitrary subdomain trusted (low)",
+		"/api/public Insecure scheme origin trusted (low)",
+		"/api/public Origin prefix match (low)",
+		"/api/public Origin suffix match (low)",
+		"/api/public null origin trusted (low)",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CORS\n%v", got)
+		t.Errorf("want\n%v", want)
+	}
+
+	if atomic.LoadInt32(&missingHeader) != 0 {
+		t.Errorf("%d CORS requests without the custom headers", atomic.LoadInt32(&missingHeader))
+	}
+}
+
+func TestActiveConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><a href="/api/1">1</a><a href="/api/2">2</a><a href="/api/3">3</a>` +
+			`<a href="/api/4">4</a><a href="/api/5">5</a><a href="/api/6">6</a></html>`))
+	})
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "" {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+
+			for {
+				maximum := atomic.LoadInt32(&maxInFlight)
+				if current <= maximum || atomic.CompareAndSwapInt32(&maxInFlight, maximum, current) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"user": "cariddi"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	crawler.New(&crawler.Scan{
+		Target:      server.URL,
+		Concurrency: 2,
+		Timeout:     5,
+		JSON:        true,
+		CORSFlag:    true,
+	})
+
+	// The active checks of all the pages share the concurrency of the scan.
+	if got := atomic.LoadInt32(&maxInFlight); got == 0 || got > 2 {
+		t.Errorf("%d concurrent active requests, want at most 2", got)
+	}
+}
@@ -0,0 +1,303 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+const (
+	// htmlSniffLength is the number of bytes checked
+	// to detect an HTML document.
+	htmlSniffLength = 512
+)
+
+// nolint: gochecknoglobals
+var (
+	gitHeadRegex        = regexp.MustCompile(`^(?:ref: refs/\S+|[0-9a-f]{40})\s*$`)
+	gitCredentialsRegex = regexp.MustCompile(`(?i)url\s*=\s*[a-z+]+://[^/\s:@]+:[^/\s@]+@`)
+	envLineRegex        = regexp.MustCompile(`(?m)^[ \t]*(?:export[ \t]+)?([A-Za-z_][A-Za-z0-9_]*)[ \t]*=`)
+	configLineRegex     = regexp.MustCompile(`(?m)^[ \t]*[\w.\-\[\]]+[ \t]*[=:]`)
+	secretKeyRegex      = regexp.MustCompile(`(?i)pass(?:word|wd)?|secret|token|api_?key|private|credential`)
+	secretAssignRegex   = regexp.MustCompile(`(?i)(?:pass(?:word|wd)?|secret|token|api_?key)\w*['"]?\s*(?:=>|=|:)`)
+	sqlDumpRegex        = regexp.MustCompile(`(?i)CREATE TABLE|INSERT INTO|DROP TABLE|-- MySQL dump|` +
+		`PostgreSQL database dump`)
+)
+
+// sourceCodeRegexes returns the patterns identifying the
+// source code of the server-side languages.
+func sourceCodeRegexes() map[string]*regexp.Regexp {
+	php := regexp.MustCompile(`<\?(?:php|=)`)
+	asp := regexp.MustCompile(`<%`)
+	perl := regexp.MustCompile(`(?m)^(?:#!.*perl|use strict|my \$)`)
+
+	return map[string]*regexp.Regexp{
+		"php":   php,
+		"php5":  php,
+		"phtml": php,
+		"asp":   asp,
+		"aspx":  asp,
+		"jsp":   regexp.MustCompile(`<%|<jsp:`),
+		"py":    regexp.MustCompile(`(?m)^(?:import \w|from \S+ import |def \w+\(|class \w+)`),
+		"rb":    regexp.MustCompile(`(?m)^(?:require |def \w+|class \w+|module \w+)`),
+		"pl":    perl,
+		"cgi":   perl,
+	}
+}
+
+// isHTMLContent checks if the content is an HTML document.
+func isHTMLContent(body []byte) bool {
+	head := body
+	if len(head) > htmlSniffLength {
+		head = head[:htmlSniffLength]
+	}
+
+	head = bytes.ToLower(head)
+
+	return bytes.Contains(head, []byte("<!doctype html")) || bytes.Contains(head, []byte("<html"))
+}
+
+// hasMagicBytes checks if the content starts with the
+// signature of a file type (true if the type has no signature).
+func hasMagicBytes(extension string, body []byte) bool {
+	for _, magic := range scanner.GetMagicBytes() {
+		if magic.Extension == extension {
+			end := magic.Offset + len(magic.Signature)
+			return len(body) >= end && string(body[magic.Offset:end]) == magic.Signature
+		}
+	}
+
+	return true
+}
+
+// ValidContent checks if the content of a file matches its
+// extension: archives and databases must have the right magic bytes,
+// .env files must contain KEY=VALUE lines, .git files must be Git
+// files and so on. Extensions without a content check are always valid.
+func ValidContent(extension string, body []byte) bool {
+	switch extension {
+	case "env":
+		return !isHTMLContent(body) && envLineRegex.Match(body)
+	case "git":
+		return gitHeadRegex.Match(bytes.TrimSpace(body)) || bytes.Contains(body, []byte("[core]"))
+	case "sql":
+		return !isHTMLContent(body) && sqlDumpRegex.Match(body)
+	case "pem", "key":
+		return bytes.Contains(body, []byte("-----BEGIN "))
+	case "tar.gz":
+		return hasMagicBytes("gz", body)
+	case "tar.bz2":
+		return hasMagicBytes("bz2", body)
+	case "zip", "gz", "tar", "rar", "7z", "db":
+		return hasMagicBytes(extension, body)
+	case "bak", "log", "passwd", "pwd", "secret", "secrets":
+		return !isHTMLContent(body)
+	}
+
+	return true
+}
+
+// huntExposures probes the version control files of the host (once)
+// and the backup copies of the crawled file. It returns the ones
+// whose content is validated.
+func huntExposures(client *activeClient, target string, vcsSeen, backupsSeen *seenSet) []scanner.ExposureMatched {
+	results := []scanner.ExposureMatched{}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return results
+	}
+
+	probes := []string{}
+	root := u.Scheme + "://" + u.Host
+
+	if vcsSeen.add(root) {
+		for _, file := range scanner.GetVCSFiles() {
+			probes = append(probes, root+file)
+		}
+	}
+
+	if backupCandidate(u.Path) && backupsSeen.add(root+u.Path) {
+		for _, variant := range scanner.GetBackupVariants(u.Path) {
+			probes = append(probes, root+variant)
+		}
+	}
+
+	for _, probe := range probes {
+		resp, body, err := client.Do(http.MethodGet, probe, nil)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			continue
+		}
+
+		if exposure, ok := ExposureMatch(probe, body); ok {
+			results = append(results, scanner.ExposureMatched{Exposure: exposure, URL: probe})
+		}
+	}
+
+	return results
+}
+
+// backupCandidate checks if the backup copies of a file should be probed.
+func backupCandidate(filePath string) bool {
+	extension := fileExtension(filePath)
+
+	for _, elem := range scanner.GetBackupExtensions() {
+		if extension == elem {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileExtension returns the lowercase extension of a path (without the dot).
+func fileExtension(filePath string) string {
+	return strings.TrimPrefix(strings.ToLower(path.Ext(filePath)), ".")
+}
+
+// ExposureMatch checks if a file is an exposed version control file,
+// .env file or backup copy, validating its content. The severity
+// depends on what the content shows.
+func ExposureMatch(target string, body []byte) (scanner.Exposure, bool) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return scanner.Exposure{}, false
+	}
+
+	filePath := u.Path
+	trimmed := bytes.TrimSpace(body)
+
+	switch {
+	case strings.HasSuffix(filePath, "/.git/HEAD"):
+		if gitHeadRegex.Match(trimmed) {
+			return scanner.Exposure{Name: "Git repository", Category: scanner.ExposureVCS,
+				Severity: scanner.SeverityHigh, Evidence: string(trimmed)}, true
+		}
+	case strings.HasSuffix(filePath, "/.git/config"):
+		if bytes.Contains(body, []byte("[core]")) {
+			if gitCredentialsRegex.Match(body) {
+				return scanner.Exposure{Name: "Git repository", Category: scanner.ExposureVCS,
+					Severity: scanner.SeverityCritical, Evidence: "remote URL with credentials"}, true
+			}
+
+			return scanner.Exposure{Name: "Git repository", Category: scanner.ExposureVCS,
+				Severity: scanner.SeverityHigh, Evidence: "Git configuration"}, true
+		}
+	case strings.HasSuffix(filePath, "/.svn/wc.db"):
+		if hasMagicBytes("db", body) {
+			return scanner.Exposure{Name: "Subversion repository", Category: scanner.ExposureVCS,
+				Severity: scanner.SeverityHigh, Evidence: "working copy database"}, true
+		}
+	case strings.HasSuffix(filePath, "/.hg/requires"):
+		if !isHTMLContent(body) && bytes.Contains(body, []byte("revlog")) {
+			return scanner.Exposure{Name: "Mercurial repository", Category: scanner.ExposureVCS,
+				Severity: scanner.SeverityHigh, Evidence: "repository requirements"}, true
+		}
+	case strings.HasPrefix(path.Base(filePath), ".env"):
+		return envExposure(body)
+	default:
+		if original, ok := scanner.BackupOriginal(filePath); ok && backupCandidate(original) {
+			return backupExposure(fileExtension(original), strings.HasSuffix(filePath, ".swp"), body)
+		}
+	}
+
+	return scanner.Exposure{}, false
+}
+
+// envExposure validates a .env file, it's critical if it defines secrets.
+func envExposure(body []byte) (scanner.Exposure, bool) {
+	if isHTMLContent(body) {
+		return scanner.Exposure{}, false
+	}
+
+	keys := []string{}
+	secrets := []string{}
+
+	for _, match := range envLineRegex.FindAllSubmatch(body, -1) {
+		keys = append(keys, string(match[1]))
+
+		if secretKeyRegex.Match(match[1]) {
+			secrets = append(secrets, string(match[1]))
+		}
+	}
+
+	if len(keys) == 0 {
+		return scanner.Exposure{}, false
+	}
+
+	if len(secrets) != 0 {
+		return scanner.Exposure{Name: "Environment file", Category: scanner.ExposureConfig,
+			Severity: scanner.SeverityCritical, Evidence: "secrets defined: " + strings.Join(secrets, ", ")}, true
+	}
+
+	return scanner.Exposure{Name: "Environment file", Category: scanner.ExposureConfig,
+		Severity: scanner.SeverityHigh, Evidence: "variables defined: " + strings.Join(keys, ", ")}, true
+}
+
+// backupExposure validates the backup copy of a file: it must show source
+// code (or configuration), it's critical if it contains secrets.
+func backupExposure(extension string, swap bool, body []byte) (scanner.Exposure, bool) {
+	exposure := scanner.Exposure{Category: scanner.ExposureBackup, Severity: scanner.SeverityHigh}
+
+	switch regex, source := sourceCodeRegexes()[extension]; {
+	case swap:
+		if !hasMagicBytes("swp", body) {
+			return scanner.Exposure{}, false
+		}
+
+		exposure.Name, exposure.Evidence = "Vim swap file", "swap file of a ."+extension+" file"
+	case isHTMLContent(body):
+		// Rendered pages and soft 404s.
+		return scanner.Exposure{}, false
+	case source:
+		if !regex.Match(body) {
+			return scanner.Exposure{}, false
+		}
+
+		exposure.Name, exposure.Evidence = "Source code backup", extension+" source code"
+	default:
+		if !configLineRegex.Match(body) {
+			return scanner.Exposure{}, false
+		}
+
+		exposure.Name, exposure.Evidence = "Configuration backup", extension+" configuration"
+	}
+
+	if secretAssignRegex.Match(body) {
+		exposure.Severity = scanner.SeverityCritical
+		exposure.Evidence += " with secrets"
+	}
+
+	return exposure, true
+}
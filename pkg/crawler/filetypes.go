@@ -0,0 +1,180 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// containers maps the file types to the
+// format their content is stored in.
+// nolint: gochecknoglobals
+var containers = map[string]string{
+	"docx":    "zip",
+	"xlsx":    "zip",
+	"odt":     "zip",
+	"apk":     "zip",
+	"jar":     "zip",
+	"war":     "zip",
+	"tar.gz":  "gz",
+	"tar.bz2": "bz2",
+}
+
+// FileTypeMatch classifies a response by the file it serves.
+// The signals are checked from the most to the least reliable: the
+// filename in the Content-Disposition header, the magic bytes of the
+// body, the Content-Type header and, as a fallback, the extension in
+// the URL path. It returns the file type and the signal matched.
+func FileTypeMatch(target string, headers http.Header, body []byte) (scanner.FileType, string, bool) {
+	dispositionExt := extensionOf(dispositionFilename(headers))
+	contentTypeExt := contentTypeExtension(headers)
+	urlExt := extensionOf(filePath(target))
+
+	if filetype, ok := lookupExtension(dispositionExt); ok && ValidContent(dispositionExt, body) {
+		return filetype, scanner.SignalDisposition, true
+	}
+
+	if magic := sniffExtension(body); magic != "" {
+		ext := magic
+
+		// A ZIP file could be a DOCX document, a GZIP one a tarball...
+		for _, hint := range []string{contentTypeExt, urlExt} {
+			if containers[hint] == magic {
+				ext = hint
+				break
+			}
+		}
+
+		if filetype, ok := lookupExtension(ext); ok {
+			return filetype, scanner.SignalMagicBytes, true
+		}
+	}
+
+	if filetype, ok := lookupExtension(contentTypeExt); ok && ValidContent(contentTypeExt, body) {
+		return filetype, scanner.SignalContentType, true
+	}
+
+	if filetype, ok := lookupExtension(urlExt); ok && ValidContent(urlExt, body) {
+		return filetype, scanner.SignalURL, true
+	}
+
+	return scanner.FileType{}, "", false
+}
+
+// lookupExtension returns the file type of an extension, if known.
+func lookupExtension(extension string) (scanner.FileType, bool) {
+	if extension == "" {
+		return scanner.FileType{}, false
+	}
+
+	for _, ext := range scanner.GetExtensions() {
+		if ext.Extension == extension {
+			return ext, true
+		}
+	}
+
+	return scanner.FileType{}, false
+}
+
+// extensionOf returns the longest known extension the
+// name ends with (tar.gz is preferred over gz).
+func extensionOf(name string) string {
+	name = strings.ToLower(name)
+	result := ""
+
+	for _, ext := range scanner.GetExtensions() {
+		if len(ext.Extension) > len(result) && strings.HasSuffix(name, "."+ext.Extension) {
+			result = ext.Extension
+		}
+	}
+
+	return result
+}
+
+// filePath returns the path of the URL, without the query and the fragment.
+func filePath(target string) string {
+	if path, err := urlUtils.GetPath(target); err == nil {
+		return path
+	}
+
+	if i := strings.IndexAny(target, "?#"); i > -1 {
+		return target[:i]
+	}
+
+	return target
+}
+
+// dispositionFilename returns the filename of the
+// Content-Disposition header (if any).
+func dispositionFilename(headers http.Header) string {
+	disposition := headers.Get("Content-Disposition")
+	if disposition == "" {
+		return ""
+	}
+
+	_, params, err := mime.ParseMediaType(disposition)
+	if err != nil {
+		return ""
+	}
+
+	return params["filename"]
+}
+
+// contentTypeExtension returns the extension associated with
+// the media type of the Content-Type header (if any).
+func contentTypeExtension(headers http.Header) string {
+	mediaType, _, err := mime.ParseMediaType(headers.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+
+	for _, contentType := range scanner.GetContentTypes() {
+		if contentType.MediaType == mediaType {
+			return contentType.Extension
+		}
+	}
+
+	return ""
+}
+
+// sniffExtension returns the extension whose magic
+// bytes the body starts with (if any).
+func sniffExtension(body []byte) string {
+	for _, magic := range scanner.GetMagicBytes() {
+		end := magic.Offset + len(magic.Signature)
+		if len(body) >= end && string(body[magic.Offset:end]) == magic.Signature {
+			return magic.Extension
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,151 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestFileTypeMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		headers    map[string]string
+		body       string
+		want       string
+		wantSignal string
+		wantOk     bool
+	}{
+		{
+			name:       "magic bytes and url suffix",
+			target:     "https://example.com/report.pdf?id=5",
+			body:       "%PDF-1.7",
+			want:       "pdf",
+			wantSignal: scanner.SignalMagicBytes,
+			wantOk:     true,
+		},
+		{
+			name:       "url suffix only",
+			target:     "https://example.com/docs/manual.docx",
+			body:       "not a zip",
+			want:       "docx",
+			wantSignal: scanner.SignalURL,
+			wantOk:     true,
+		},
+		{
+			name:       "longest suffix",
+			target:     "https://example.com/dump.TAR.GZ",
+			body:       "\x1f\x8b\x08\x00",
+			want:       "tar.gz",
+			wantSignal: scanner.SignalMagicBytes,
+			wantOk:     true,
+		},
+		{
+			name:       "content disposition",
+			target:     "https://example.com/download?id=5",
+			headers:    map[string]string{"Content-Disposition": `attachment; filename="backup.sql"`},
+			body:       "-- MySQL dump 10.13\nCREATE TABLE `users` (",
+			want:       "sql",
+			wantSignal: scanner.SignalDisposition,
+			wantOk:     true,
+		},
+		{
+			name:       "magic bytes",
+			target:     "https://example.com/export",
+			body:       "PK\x03\x04\x14\x00",
+			want:       "zip",
+			wantSignal: scanner.SignalMagicBytes,
+			wantOk:     true,
+		},
+		{
+			name:       "magic bytes refined by content type",
+			target:     "https://example.com/export",
+			headers:    map[string]string{"Content-Type": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+			body:       "PK\x03\x04\x14\x00",
+			want:       "xlsx",
+			wantSignal: scanner.SignalMagicBytes,
+			wantOk:     true,
+		},
+		{
+			name:       "content type",
+			target:     "https://example.com/download?id=5",
+			headers:    map[string]string{"Content-Type": "text/csv; charset=utf-8"},
+			body:       "id,name\n1,admin\n",
+			want:       "csv",
+			wantSignal: scanner.SignalContentType,
+			wantOk:     true,
+		},
+		{
+			name:    "content type soft 404",
+			target:  "https://example.com/export",
+			headers: map[string]string{"Content-Type": "application/zip"},
+			body:    notFoundPage,
+			wantOk:  false,
+		},
+		{
+			name:    "url suffix soft 404",
+			target:  "https://example.com/backup.zip",
+			headers: map[string]string{"Content-Type": "text/html"},
+			body:    notFoundPage,
+			wantOk:  false,
+		},
+		{
+			name:    "web page",
+			target:  "https://example.com/login",
+			headers: map[string]string{"Content-Type": "text/html; charset=utf-8"},
+			body:    notFoundPage,
+			wantOk:  false,
+		},
+		{
+			name:   "short url",
+			target: "a",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			for name, value := range tt.headers {
+				headers.Set(name, value)
+			}
+
+			got, signal, ok := crawler.FileTypeMatch(tt.target, headers, []byte(tt.body))
+			if ok != tt.wantOk {
+				t.Fatalf("FileTypeMatch() ok = %v, want %v", ok, tt.wantOk)
+			}
+
+			if got.Extension != tt.want || signal != tt.wantSignal {
+				t.Errorf("FileTypeMatch() = %v (%v), want %v (%v)", got.Extension, signal, tt.want, tt.wantSignal)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+const (
+	// hstsMinMaxAge is the minimum HSTS max-age (180 days).
+	hstsMinMaxAge = 15552000
+)
+
+// huntHeaders hunts for missing, weak and information
+// leaking headers.
+func huntHeaders(target string, headers *http.Header) []scanner.HeaderMatched {
+	headersSlice := HeadersMatch(target, headers)
+	return headersSlice
+}
+
+// HeadersMatch checks the headers of a response.
+// Information leaking and debug headers are checked in all the
+// responses, while security headers only in HTML pages.
+func HeadersMatch(target string, headers *http.Header) []scanner.HeaderMatched {
+	host := urlUtils.GetHost(target)
+	issues := []scanner.HeaderMatched{}
+
+	found := func(name, httpHeader, match string) {
+		header := scanner.GetHeaderIssue(name)
+		if httpHeader != "" {
+			header.HTTPHeader = httpHeader
+		}
+
+		issues = append(issues, scanner.HeaderMatched{Header: header, Host: host, URL: target, Match: match})
+	}
+
+	for _, leaking := range scanner.GetLeakingHeaders() {
+		if value := headers.Get(leaking); value != "" {
+			found(scanner.HeaderInfoLeak, leaking, value)
+		}
+	}
+
+	for _, debug := range scanner.GetDebugHeaders() {
+		if value := headers.Get(debug); value != "" {
+			found(scanner.HeaderDebug, debug, value)
+		}
+	}
+
+	if !strings.Contains(strings.ToLower(headers.Get("Content-Type")), "text/html") {
+		return issues
+	}
+
+	// Content-Security-Policy
+	csp := headers.Get("Content-Security-Policy")
+	policy := ParseCSP(csp)
+
+	if csp == "" {
+		found(scanner.HeaderMissingCSP, "", "")
+	} else {
+		for _, issue := range checkCSP(policy) {
+			found(issue[0], "", issue[1])
+		}
+	}
+
+	// Strict-Transport-Security
+	if strings.HasPrefix(strings.ToLower(target), "https://") {
+		hsts := headers.Get("Strict-Transport-Security")
+		if hsts == "" {
+			found(scanner.HeaderMissingHSTS, "", "")
+		} else {
+			maxAge, includeSubdomains := parseHSTS(hsts)
+			if maxAge < hstsMinMaxAge {
+				found(scanner.HeaderHSTSShortMaxAge, "", hsts)
+			}
+
+			if !includeSubdomains {
+				found(scanner.HeaderHSTSNoSubdomains, "", hsts)
+			}
+		}
+	}
+
+	// X-Frame-Options
+	xfo := strings.ToUpper(strings.TrimSpace(headers.Get("X-Frame-Options")))
+	_, frameAncestors := policy["frame-ancestors"]
+
+	switch {
+	case xfo == "" && !frameAncestors:
+		found(scanner.HeaderMissingXFrameOptions, "", "")
+	case xfo != "" && xfo != "DENY" && xfo != "SAMEORIGIN":
+		found(scanner.HeaderWeakXFrameOptions, "", headers.Get("X-Frame-Options"))
+	}
+
+	// Referrer-Policy
+	referrer := headers.Get("Referrer-Policy")
+	if referrer == "" {
+		found(scanner.HeaderMissingReferrerPolicy, "", "")
+	} else {
+		// Browsers use the last policy they support.
+		policies := strings.Split(referrer, ",")
+		last := strings.ToLower(strings.TrimSpace(policies[len(policies)-1]))
+
+		if last == "unsafe-url" || last == "no-referrer-when-downgrade" {
+			found(scanner.HeaderWeakReferrerPolicy, "", referrer)
+		}
+	}
+
+	// Permissions-Policy (formerly Feature-Policy)
+	if headers.Get("Permissions-Policy") == "" && headers.Get("Feature-Policy") == "" {
+		found(scanner.HeaderMissingPermissionsPolicy, "", "")
+	}
+
+	return issues
+}
+
+// ParseCSP parses a Content Security Policy and returns
+// the directives with their (lowercase) source lists.
+// If the policy contains the same directive more than once
+// only the first one is considered, as browsers do.
+func ParseCSP(csp string) map[string][]string {
+	policy := make(map[string][]string)
+
+	for _, directive := range strings.Split(csp, ";") {
+		fields := strings.Fields(strings.ToLower(directive))
+		if len(fields) == 0 {
+			continue
+		}
+
+		if _, ok := policy[fields[0]]; !ok {
+			policy[fields[0]] = fields[1:]
+		}
+	}
+
+	return policy
+}
+
+// checkCSP checks a parsed Content Security Policy and returns
+// the issues found as (name, directive) pairs.
+func checkCSP(policy map[string][]string) [][2]string {
+	issues := [][2]string{}
+
+	scriptDirective := "script-src"
+	scriptSources, ok := policy[scriptDirective]
+
+	if !ok {
+		scriptDirective = "default-src"
+		scriptSources, ok = policy[scriptDirective]
+	}
+
+	if !ok {
+		issues = append(issues, [2]string{scanner.HeaderCSPNoScriptRestriction, ""})
+	} else {
+		directive := strings.TrimSpace(scriptDirective + " " + strings.Join(scriptSources, " "))
+		unsafeInline, unsafeEval, wildcard, insecure, nonceOrHash := false, false, false, false, false
+
+		for _, source := range scriptSources {
+			switch {
+			case source == "'unsafe-inline'":
+				unsafeInline = true
+			case source == "'unsafe-eval'":
+				unsafeEval = true
+			case source == "*":
+				wildcard = true
+			case source == "http:" || source == "data:" || source == "blob:" ||
+				strings.HasPrefix(source, "http://"):
+				insecure = true
+			case strings.HasPrefix(source, "'nonce-") || strings.HasPrefix(source, "'sha") ||
+				source == "'strict-dynamic'":
+				nonceOrHash = true
+			}
+		}
+
+		// 'unsafe-inline' is ignored by browsers if a nonce or a hash is present.
+		if unsafeInline && !nonceOrHash {
+			issues = append(issues, [2]string{scanner.HeaderCSPUnsafeInline, directive})
+		}
+
+		if unsafeEval {
+			issues = append(issues, [2]string{scanner.HeaderCSPUnsafeEval, directive})
+		}
+
+		if wildcard {
+			issues = append(issues, [2]string{scanner.HeaderCSPWildcard, directive})
+		}
+
+		if insecure {
+			issues = append(issues, [2]string{scanner.HeaderCSPInsecureScheme, directive})
+		}
+	}
+
+	_, objectSrc := policy["object-src"]
+	_, defaultSrc := policy["default-src"]
+
+	if !objectSrc && !defaultSrc {
+		issues = append(issues, [2]string{scanner.HeaderCSPNoObjectRestriction, ""})
+	}
+
+	return issues
+}
+
+// parseHSTS parses a Strict-Transport-Security header and returns
+// the max-age value and whether subdomains are included.
+func parseHSTS(hsts string) (int, bool) {
+	maxAge := 0
+	includeSubdomains := false
+
+	for _, directive := range strings.Split(hsts, ";") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+
+		switch {
+		case strings.HasPrefix(directive, "max-age="):
+			value, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(directive, "max-age="), `"`))
+			if err == nil {
+				maxAge = value
+			}
+		case directive == "includesubdomains":
+			includeSubdomains = true
+		}
+	}
+
+	return maxAge, includeSubdomains
+}
@@ -0,0 +1,304 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestParseCSP(t *testing.T) {
+	tests := []struct {
+		name string
+		csp  string
+		want map[string][]string
+	}{
+		{
+			name: "empty policy",
+			csp:  "",
+			want: map[string][]string{},
+		},
+		{
+			name: "directives",
+			csp:  "default-src 'self'; script-src 'self' https://cdn.test.com; object-src 'none'",
+			want: map[string][]string{
+				"default-src": {"'self'"},
+				"script-src":  {"'self'", "https://cdn.test.com"},
+				"object-src":  {"'none'"},
+			},
+		},
+		{
+			name: "case and empty directives",
+			csp:  "Script-Src 'SELF' 'Unsafe-Inline';; ;upgrade-insecure-requests",
+			want: map[string][]string{
+				"script-src":                {"'self'", "'unsafe-inline'"},
+				"upgrade-insecure-requests": {},
+			},
+		},
+		{
+			name: "duplicate directive",
+			csp:  "script-src 'self'; script-src *",
+			want: map[string][]string{
+				"script-src": {"'self'"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := crawler.ParseCSP(tt.csp); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCSP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// secureHeaders returns the headers of a HTML page without issues,
+// overridden by the headers given (an empty value deletes the header).
+func secureHeaders(override map[string]string) *http.Header {
+	headers := http.Header{}
+	headers.Set("Content-Type", "text/html; charset=utf-8")
+	headers.Set("Content-Security-Policy", "default-src 'self'")
+	headers.Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+	headers.Set("X-Frame-Options", "DENY")
+	headers.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+	headers.Set("Permissions-Policy", "camera=()")
+
+	for name, value := range override {
+		if value == "" {
+			headers.Del(name)
+		} else {
+			headers.Set(name, value)
+		}
+	}
+
+	return &headers
+}
+
+func TestHeadersMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		override map[string]string
+		want     []string
+	}{
+		{
+			name:   "secure page",
+			target: "https://test.com/",
+			want:   []string{},
+		},
+		{
+			name:     "security headers not checked outside HTML pages",
+			target:   "https://test.com/app.js",
+			override: map[string]string{"Content-Type": "text/javascript", "Content-Security-Policy": ""},
+			want:     []string{},
+		},
+		{
+			name:     "leaking and debug headers",
+			target:   "https://test.com/app.js",
+			override: map[string]string{"Content-Type": "text/javascript", "Server": "nginx/1.18.0", "X-Debug-Token": "a1b2c3"},
+			want:     []string{"Information leaking header (Server): nginx/1.18.0", "Debug header (X-Debug-Token): a1b2c3"},
+		},
+		{
+			name:     "missing CSP",
+			target:   "https://test.com/",
+			override: map[string]string{"Content-Security-Policy": ""},
+			want:     []string{"Missing Content-Security-Policy (Content-Security-Policy): "},
+		},
+		{
+			name:     "unsafe-inline",
+			target:   "https://test.com/",
+			override: map[string]string{"Content-Security-Policy": "default-src 'self' 'unsafe-inline'"},
+			want: []string{
+				"CSP allows unsafe-inline scripts (Content-Security-Policy): default-src 'self' 'unsafe-inline'",
+			},
+		},
+		{
+			name:   "unsafe-inline ignored with a nonce",
+			target: "https://test.com/",
+			override: map[string]string{
+				"Content-Security-Policy": "default-src 'self'; script-src 'unsafe-inline' 'nonce-r4nd0m'",
+			},
+			want: []string{},
+		},
+		{
+			name:   "unsafe-eval, wildcard and insecure scheme",
+			target: "https://test.com/",
+			override: map[string]string{
+				"Content-Security-Policy": "default-src 'self'; script-src * 'unsafe-eval' http:",
+			},
+			want: []string{
+				"CSP allows unsafe-eval (Content-Security-Policy): script-src * 'unsafe-eval' http:",
+				"CSP allows scripts from any source (Content-Security-Policy): script-src * 'unsafe-eval' http:",
+				"CSP allows scripts from insecure schemes (Content-Security-Policy): script-src * 'unsafe-eval' http:",
+			},
+		},
+		{
+			name:     "missing default-src",
+			target:   "https://test.com/",
+			override: map[string]string{"Content-Security-Policy": "img-src 'self'; frame-ancestors 'none'"},
+			want: []string{
+				"CSP does not restrict scripts (Content-Security-Policy): ",
+				"CSP does not restrict plugins (Content-Security-Policy): ",
+			},
+		},
+		{
+			name:     "script-src without default-src",
+			target:   "https://test.com/",
+			override: map[string]string{"Content-Security-Policy": "script-src 'self'"},
+			want:     []string{"CSP does not restrict plugins (Content-Security-Policy): "},
+		},
+		{
+			name:     "missing HSTS",
+			target:   "https://test.com/",
+			override: map[string]string{"Strict-Transport-Security": ""},
+			want:     []string{"Missing Strict-Transport-Security (Strict-Transport-Security): "},
+		},
+		{
+			name:     "HSTS not checked over HTTP",
+			target:   "http://test.com/",
+			override: map[string]string{"Strict-Transport-Security": ""},
+			want:     []string{},
+		},
+		{
+			name:     "short HSTS max-age",
+			target:   "https://test.com/",
+			override: map[string]string{"Strict-Transport-Security": "max-age=86400; includeSubDomains"},
+			want: []string{
+				"HSTS max-age too short (Strict-Transport-Security): max-age=86400; includeSubDomains",
+			},
+		},
+		{
+			name:     "invalid HSTS max-age",
+			target:   "https://test.com/",
+			override: map[string]string{"Strict-Transport-Security": "max-age=forever; includeSubDomains"},
+			want: []string{
+				"HSTS max-age too short (Strict-Transport-Security): max-age=forever; includeSubDomains",
+			},
+		},
+		{
+			name:     "quoted HSTS max-age without includeSubDomains",
+			target:   "https://test.com/",
+			override: map[string]string{"Strict-Transport-Security": `max-age="31536000"`},
+			want: []string{
+				`HSTS without includeSubDomains (Strict-Transport-Security): max-age="31536000"`,
+			},
+		},
+		{
+			name:   "frame-ancestors instead of X-Frame-Options",
+			target: "https://test.com/",
+			override: map[string]string{
+				"X-Frame-Options":         "",
+				"Content-Security-Policy": "default-src 'self'; frame-ancestors 'none'",
+			},
+			want: []string{},
+		},
+		{
+			name:     "weak X-Frame-Options",
+			target:   "https://test.com/",
+			override: map[string]string{"X-Frame-Options": "ALLOW-FROM https://evil.com"},
+			want:     []string{"Weak X-Frame-Options (X-Frame-Options): ALLOW-FROM https://evil.com"},
+		},
+		{
+			name:     "weak Referrer-Policy",
+			target:   "https://test.com/",
+			override: map[string]string{"Referrer-Policy": "no-referrer, unsafe-url"},
+			want:     []string{"Weak Referrer-Policy (Referrer-Policy): no-referrer, unsafe-url"},
+		},
+		{
+			name:     "Feature-Policy instead of Permissions-Policy",
+			target:   "https://test.com/",
+			override: map[string]string{"Permissions-Policy": "", "Feature-Policy": "camera 'none'"},
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, elem := range crawler.HeadersMatch(tt.target, secureHeaders(tt.override)) {
+				got = append(got, elem.Header.Name+" ("+elem.Header.HTTPHeader+"): "+elem.Match)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HeadersMatch()\n%v", got)
+				t.Errorf("want\n%v", tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Server", "nginx/1.18.0")
+		_, _ = w.Write([]byte(`<html><a href="/a">a</a><a href="/b">b</a></html>`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	results := crawler.New(&crawler.Scan{
+		Target:      server.URL,
+		Concurrency: 1,
+		Timeout:     5,
+		JSON:        true,
+		SecHeaders:  true,
+	})
+
+	got := []string{}
+	for _, elem := range results.Headers {
+		got = append(got, elem.Header.Name)
+	}
+
+	sort.Strings(got)
+
+	// Each issue is reported once per host, even if found in all the pages.
+	want := []string{
+		scanner.HeaderInfoLeak,
+		scanner.HeaderMissingCSP,
+		scanner.HeaderMissingPermissionsPolicy,
+		scanner.HeaderMissingReferrerPolicy,
+		scanner.HeaderMissingXFrameOptions,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Headers\n%v", got)
+		t.Errorf("want\n%v", want)
+	}
+
+	if len(results.URLs) != 2 {
+		t.Errorf("URLs %v, want 2", results.URLs)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// nolint: gochecknoglobals
+var (
+	hostLinkRegex = regexp.MustCompile(`(?i)\b(?:href|src|action)\s*=\s*["']?(?:https?:)?//([^/"'\s>?#\\]+)`)
+	hostURLRegex  = regexp.MustCompile(`(?i)(?:https?:)?//([a-z0-9](?:[a-z0-9-]*[a-z0-9])?` +
+		`(?:\.[a-z0-9](?:[a-z0-9-]*[a-z0-9])?)+)`)
+	hostnameRegex = regexp.MustCompile(`^(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+	// rootRegexes caches the compiled rootHostRegex of each root domain.
+	rootRegexes sync.Map
+)
+
+// huntHosts hunts for the hostnames mentioned in a response
+// and in the TLS certificate of the host.
+// Certificates are not retrieved when a proxy is used, since
+// they require a direct connection.
+func huntHosts(target string, requestURL *url.URL, headers *http.Header, body string,
+	scan *Scan, certSeen *seenSet) []scanner.HostMatched {
+	hostsSlice := HostsMatch(target, requestURL.String(), headers, body)
+
+	if scan.Proxy == "" && requestURL.Scheme == "https" && certSeen.add(requestURL.Host) {
+		for _, name := range certificateHosts(requestURL.Host, scan.Timeout) {
+			if host, ok := newHost(target, name, scanner.HostSourceCertificate, requestURL.String()); ok {
+				hostsSlice = append(hostsSlice, host)
+			}
+		}
+	}
+
+	return hostsSlice
+}
+
+// HostsMatch collects the hostnames mentioned in the links, the body
+// (HTML and JavaScript), the Content-Security-Policy and the
+// Access-Control-Allow-Origin headers of a response and classifies
+// them with respect to the target host.
+func HostsMatch(target string, requestURL string, headers *http.Header, body string) []scanner.HostMatched {
+	hosts := []scanner.HostMatched{}
+	seen := make(map[string]bool)
+
+	add := func(name, source string) {
+		if host, ok := newHost(target, name, source, requestURL); ok && !seen[host.Host.Name] {
+			seen[host.Host.Name] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	for _, sources := range ParseCSP(headers.Get("Content-Security-Policy")) {
+		for _, source := range sources {
+			if !strings.HasPrefix(source, "'") {
+				add(strings.Split(urlUtils.RemoveProtocol(source), "/")[0], scanner.HostSourceCSP)
+			}
+		}
+	}
+
+	if origin := headers.Get("Access-Control-Allow-Origin"); urlUtils.HasProtocol(origin) {
+		add(urlUtils.GetHost(origin), scanner.HostSourceCORS)
+	}
+
+	for _, match := range hostLinkRegex.FindAllStringSubmatch(body, -1) {
+		add(match[1], scanner.HostSourceLink)
+	}
+
+	bodySource := scanner.HostSourceBody
+	if strings.Contains(strings.ToLower(headers.Get("Content-Type")), "javascript") ||
+		strings.HasSuffix(strings.Split(requestURL, "?")[0], ".js") {
+		bodySource = scanner.HostSourceJavaScript
+	}
+
+	for _, match := range hostURLRegex.FindAllStringSubmatch(body, -1) {
+		add(match[1], bodySource)
+	}
+
+	// Hostnames under the target root domain are collected even
+	// if they are not part of a URL (e.g. "api.example.com" in a script).
+	// They must follow a delimiter, to skip property accesses like window.example.com.
+	if root, err := urlUtils.GetRootHost(target); err == nil && net.ParseIP(target) == nil {
+		for _, match := range rootHostRegex(root).FindAllStringSubmatch(body, -1) {
+			if name := strings.TrimRight(strings.ToLower(match[1]), ".-"); strings.HasSuffix(name, "."+root) {
+				add(name, bodySource)
+			}
+		}
+	}
+
+	return hosts
+}
+
+// rootHostRegex returns the regex matching the hostnames under
+// a root domain. It's compiled only once for each root domain.
+func rootHostRegex(root string) *regexp.Regexp {
+	if cached, ok := rootRegexes.Load(root); ok {
+		return cached.(*regexp.Regexp)
+	}
+
+	rootRegex := regexp.MustCompile("(?i)(?:^|[\"'`\\s/@>(])([a-z0-9][a-z0-9.-]*\\." +
+		regexp.QuoteMeta(root) + "[a-z0-9.-]*)")
+	cached, _ := rootRegexes.LoadOrStore(root, rootRegex)
+
+	return cached.(*regexp.Regexp)
+}
+
+// newHost normalizes and classifies a hostname.
+// It returns false if the hostname is not valid or
+// it's the target itself.
+func newHost(target, name, source, requestURL string) (scanner.HostMatched, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if index := strings.LastIndex(name, "@"); index >= 0 {
+		name = name[index+1:]
+	}
+
+	name = strings.TrimPrefix(urlUtils.RemovePort(name), "*.")
+	name = strings.TrimSuffix(name, ".")
+
+	if !hostnameRegex.MatchString(name) || name == target {
+		return scanner.HostMatched{}, false
+	}
+
+	host := scanner.Host{Name: name, Scope: hostScope(target, name), Source: source}
+
+	return scanner.HostMatched{Host: host, URL: requestURL}, true
+}
+
+// hostScope classifies a hostname with respect to the target host.
+func hostScope(target, name string) string {
+	if strings.HasSuffix(name, "."+target) {
+		return scanner.HostScopeSubdomain
+	}
+
+	targetRoot, err := urlUtils.GetRootHost(target)
+	if err != nil || net.ParseIP(target) != nil {
+		return scanner.HostScopeThirdParty
+	}
+
+	if root, err := urlUtils.GetRootHost(name); err == nil && root == targetRoot {
+		return scanner.HostScopeSameRoot
+	}
+
+	return scanner.HostScopeThirdParty
+}
+
+// certificateHosts returns the hostnames in the TLS certificate
+// (subject alternative names and common name) of a host.
+func certificateHosts(address string, timeout int) []string {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address += ":443"
+	}
+
+	dialer := &net.Dialer{Timeout: time.Duration(timeout) * time.Second}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{InsecureSkipVerify: true}) // nolint: gosec
+	if err != nil {
+		return []string{}
+	}
+	defer conn.Close()
+
+	certificates := conn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return []string{}
+	}
+
+	return append(certificates[0].DNSNames, certificates[0].Subject.CommonName)
+}
@@ -0,0 +1,108 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/output"
+)
+
+func TestHostsMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		url     string
+		headers http.Header
+		body    string
+		want    []string
+	}{
+		{
+			name:    "no hosts",
+			target:  "www.example.com",
+			url:     "https://www.example.com/",
+			headers: http.Header{"Content-Type": {"text/html"}},
+			body:    `<a href="/about">About</a><a href="https://www.example.com/x">X</a>`,
+			want:    []string{},
+		},
+		{
+			name:    "links and scopes",
+			target:  "www.example.com",
+			url:     "https://www.example.com/",
+			headers: http.Header{"Content-Type": {"text/html"}},
+			body: `<a href="https://blog.www.example.com/">Blog</a>` +
+				`<script src="//cdn.example.com/app.js"></script><img src="https://cdn.other.net:8443/a.png">`,
+			want: []string{
+				"blog.www.example.com (subdomain, link)",
+				"cdn.example.com (same root, link)",
+				"cdn.other.net (third party, link)",
+			},
+		},
+		{
+			name:    "javascript",
+			target:  "example.com",
+			url:     "https://example.com/static/app.js",
+			headers: http.Header{"Content-Type": {"application/javascript"}},
+			body:    `var api="api.example.com";fetch("https://metrics.vendor.io/v1");var n=window.example.com;`,
+			want: []string{
+				"metrics.vendor.io (third party, javascript)",
+				"api.example.com (subdomain, javascript)",
+			},
+		},
+		{
+			name:   "csp and cors headers",
+			target: "example.com",
+			url:    "https://example.com/api/user",
+			headers: http.Header{
+				"Content-Type":                {"application/json"},
+				"Content-Security-Policy":     {"default-src 'self'; script-src https://*.googleapis.com 'nonce-abc'"},
+				"Access-Control-Allow-Origin": {"https://app.example.com"},
+			},
+			body: `{"id": 1}`,
+			want: []string{
+				"googleapis.com (third party, csp)",
+				"app.example.com (subdomain, cors)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, elem := range crawler.HostsMatch(tt.target, tt.url, &tt.headers, tt.body) {
+				got = append(got, output.FormatHost(elem))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HostsMatch\n%v", got)
+				t.Errorf("want\n%v", tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	versionUtils "github.com/edoardottt/cariddi/internal/version"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// jsExtractor is a compiled version extractor.
+type jsExtractor struct {
+	library string
+	regex   *regexp.Regexp
+}
+
+// JSLibraries is a compiled vulnerabilities database.
+type JSLibraries struct {
+	repository scanner.JSRepository
+	uri        []jsExtractor
+	filename   []jsExtractor
+	content    []jsExtractor
+}
+
+// NewJSLibraries compiles a vulnerabilities database.
+// Extractors not supported by the Go regexp syntax are skipped.
+func NewJSLibraries(repository scanner.JSRepository) *JSLibraries {
+	names := make([]string, 0, len(repository))
+	for name := range repository {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	libraries := &JSLibraries{repository: repository}
+
+	for _, name := range names {
+		extractors := repository[name].Extractors
+		libraries.uri = append(libraries.uri, compileExtractors(name, extractors.URI)...)
+		libraries.filename = append(libraries.filename, compileExtractors(name, extractors.Filename)...)
+		libraries.content = append(libraries.content, compileExtractors(name, extractors.FileContent)...)
+	}
+
+	return libraries
+}
+
+// loadJSLibraries loads and compiles the vulnerabilities database.
+// If filename is empty the default database is used.
+func loadJSLibraries(filename string) *JSLibraries {
+	if filename == "" {
+		return NewJSLibraries(scanner.GetJSRepository())
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	repository, err := scanner.ParseJSRepository(data)
+	if err != nil {
+		fmt.Println("The vulnerabilities file " + filename + " is not valid: " + err.Error())
+		os.Exit(1)
+	}
+
+	return NewJSLibraries(repository)
+}
+
+// compileExtractors compiles the version extractors of a library.
+func compileExtractors(library string, extractors []string) []jsExtractor {
+	result := []jsExtractor{}
+
+	for _, extractor := range extractors {
+		expression := strings.ReplaceAll(extractor, scanner.JSVersionPlaceholder, scanner.JSVersionRegex)
+
+		regex, err := regexp.Compile(expression)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, jsExtractor{library: library, regex: regex})
+	}
+
+	return result
+}
+
+// huntJSLibraries hunts for vulnerable JavaScript libraries.
+func huntJSLibraries(libraries *JSLibraries, target string,
+	headers *http.Header, body string) []scanner.JSLibraryMatched {
+	librariesSlice := JSLibrariesMatch(libraries, target, headers, body)
+	return librariesSlice
+}
+
+// JSLibrariesMatch extracts the versions of the JavaScript libraries
+// from the URL, the file name and the content of a script and checks
+// them against the vulnerabilities database.
+// Only the vulnerable libraries are returned.
+func JSLibrariesMatch(libraries *JSLibraries, target string,
+	headers *http.Header, body string) []scanner.JSLibraryMatched {
+	result := []scanner.JSLibraryMatched{}
+	detected := make(map[string]bool)
+	targetPath, _ := urlUtils.GetPath(target)
+	contentType := strings.ToLower(headers.Get("Content-Type"))
+	isScript := strings.Contains(contentType, "javascript") || strings.HasSuffix(targetPath, ".js")
+
+	extract := func(extractors []jsExtractor, value string) {
+		for _, extractor := range extractors {
+			if detected[extractor.library] {
+				continue
+			}
+
+			groups := extractor.regex.FindStringSubmatch(value)
+			if len(groups) < 2 || groups[1] == "" {
+				continue
+			}
+
+			detected[extractor.library] = true
+
+			library := scanner.JSLibrary{Name: extractor.library, Version: groups[1]}
+			for _, vulnerability := range libraries.repository[extractor.library].Vulnerabilities {
+				if versionUtils.InRange(library.Version, vulnerability.AtOrAbove, vulnerability.Below) {
+					library.Vulnerabilities = append(library.Vulnerabilities, vulnerability)
+
+					if scanner.SeverityRank(vulnerability.Severity) > scanner.SeverityRank(library.Severity) {
+						library.Severity = vulnerability.Severity
+					}
+				}
+			}
+
+			if len(library.Vulnerabilities) != 0 {
+				result = append(result, scanner.JSLibraryMatched{JSLibrary: library, URL: target, Match: groups[0]})
+			}
+		}
+	}
+
+	extract(libraries.uri, target)
+	extract(libraries.filename, path.Base(targetPath))
+
+	if isScript {
+		extract(libraries.content, body)
+	}
+
+	return result
+}
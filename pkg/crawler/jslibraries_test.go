@@ -0,0 +1,99 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/output"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestJSLibrariesMatch(t *testing.T) {
+	libraries := crawler.NewJSLibraries(scanner.GetJSRepository())
+	script := http.Header{"Content-Type": {"application/javascript"}}
+	tests := []struct {
+		name    string
+		target  string
+		headers http.Header
+		body    string
+		want    []string
+	}{
+		{
+			name:    "version in the uri",
+			target:  "https://cdnjs.cloudflare.com/ajax/libs/jquery/3.4.1/jquery.min.js",
+			headers: script,
+			body:    "",
+			want:    []string{"jquery 3.4.1 (medium) - CVE-2020-11022, CVE-2020-11023"},
+		},
+		{
+			name:    "version in the file name",
+			target:  "https://example.com/js/lodash-4.17.15.min.js",
+			headers: script,
+			body:    "",
+			want:    []string{"lodash 4.17.15 (high) - CVE-2020-8203, CVE-2021-23337"},
+		},
+		{
+			name:    "version in the content",
+			target:  "https://example.com/js/vendor.js",
+			headers: script,
+			body:    "/*! jQuery UI - v1.12.1 - 2016-09-14\n* http://jqueryui.com */",
+			want: []string{"jquery-ui 1.12.1 (medium) - CVE-2021-41182, CVE-2021-41183, CVE-2021-41184," +
+				" CVE-2022-31160"},
+		},
+		{
+			name:    "fixed version",
+			target:  "https://example.com/js/jquery-3.7.1.min.js",
+			headers: script,
+			body:    "/*! jQuery v3.7.1 | (c) OpenJS Foundation and other contributors */",
+			want:    []string{},
+		},
+		{
+			name:    "content ignored outside scripts",
+			target:  "https://example.com/docs.html",
+			headers: http.Header{"Content-Type": {"text/html"}},
+			body:    "/*! jQuery v1.8.3 */",
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, elem := range crawler.JSLibrariesMatch(libraries, tt.target, &tt.headers, tt.body) {
+				got = append(got, output.FormatJSLibrary(elem))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JSLibrariesMatch\n%v", got)
+				t.Errorf("want\n%v", tt.want)
+			}
+		})
+	}
+}
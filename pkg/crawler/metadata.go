@@ -0,0 +1,578 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+	"github.com/gocolly/colly"
+)
+
+const (
+	maxDocumentEntrySize = 5 << 20
+	maxDocumentEntries   = 1000
+	maxPDFStreams        = 500
+	pdfHeaderOffset      = 1024
+	devModeNameLength    = 64
+)
+
+// ErrEntryTooBig is returned when a file inside
+// an archive exceeds the size limit.
+var ErrEntryTooBig = errors.New("archive entry too big")
+
+// nolint: gochecknoglobals
+var (
+	pdfInfoRegex     = regexp.MustCompile(`/(Author|Creator|Producer|Company|Title|Subject|Keywords)\s*[(<]`)
+	pdfStreamRegex   = regexp.MustCompile(`stream\r?\n`)
+	pdfTextRegex     = regexp.MustCompile(`(?s)\[(.*?)\]\s*TJ|(\((?:\\.|[^\\)])*\))\s*(?:Tj|'|")`)
+	pdfLiteralRegex  = regexp.MustCompile(`(?s)\((?:\\.|[^\\)])*\)`)
+	xmlTagRegex      = regexp.MustCompile(`<[^>]+>`)
+	rdfItemRegex     = regexp.MustCompile(`<rdf:li[^>]*>([^<]*)</rdf:li>`)
+	relsTargetRegex  = regexp.MustCompile(`Target="((?:file:|\\\\|[A-Za-z]:\\)[^"]+)"`)
+	absPathRegex     = regexp.MustCompile(`absPath[^>]*\burl="([^"]+)"`)
+	ooxmlAuthorRegex = regexp.MustCompile(`\bw:author="([^"]+)"|<author>([^<]+)</author>`)
+	windowsPathRegex = regexp.MustCompile(`(?i)(?:\b[a-z]:\\|\\\\[\w.$-]+\\)[^\s<>"'|*?:;,]+`)
+	unixPathRegex    = regexp.MustCompile(`(?:^|[\s"'(=]|file://)` +
+		`(/(?:home|Users|root|opt|srv|mnt|media)/[\w.@+-]+(?:/[\w.@+-]+)*/?)`)
+	pathUsernameRegex = regexp.MustCompile(`(?i)(?:\\(?:users|documents and settings)\\|/(?:home|users)/)([^\\/]+)`)
+	ignoredUsernames  = []string{"public", "default", "all users", "shared"}
+	ooxmlTextEntries  = regexp.MustCompile(`^(?:word/(?:document|header\d*|footer\d*|comments|footnotes|endnotes)` +
+		`\.xml|xl/(?:sharedStrings|worksheets/sheet\d+|comments\d*)\.xml|ppt/(?:slides|notesSlides)/\w+\.xml|` +
+		`docProps/custom\.xml)$`)
+	// xmlValueRegexes are the compiled regexes of the XML elements read.
+	xmlValueRegexes = compileXMLValueRegexes()
+)
+
+// xmlValueRegex contains the regexes matching
+// the values of an XML element (or attribute).
+type xmlValueRegex struct {
+	element   *regexp.Regexp
+	attribute *regexp.Regexp
+}
+
+// compileXMLValueRegexes compiles the regexes of the XML elements read
+// from the documents: the XMP tags and the OOXML properties.
+func compileXMLValueRegexes() map[string]xmlValueRegex {
+	tags := []string{"Application", "AppVersion", "Template"}
+
+	for tag := range scanner.GetXMPTags() {
+		tags = append(tags, tag)
+	}
+
+	for tag := range scanner.GetOOXMLProperties() {
+		tags = append(tags, tag)
+	}
+
+	regexes := make(map[string]xmlValueRegex, len(tags))
+	for _, tag := range tags {
+		regexes[tag] = compileXMLValueRegex(tag)
+	}
+
+	return regexes
+}
+
+// compileXMLValueRegex compiles the regexes matching
+// the values of an XML element (or attribute).
+func compileXMLValueRegex(tag string) xmlValueRegex {
+	quoted := regexp.QuoteMeta(tag)
+
+	return xmlValueRegex{
+		element:   regexp.MustCompile(`(?s)<` + quoted + `(?:\s[^>]*)?>(.*?)</` + quoted + `>`),
+		attribute: regexp.MustCompile(`\s` + quoted + `="([^"]*)"`),
+	}
+}
+
+// huntDocument extracts the metadata and the text of the PDF and Office
+// documents. The metadata are reported as infos and the secrets and
+// infos hunters run over the text extracted.
+func huntDocument(r *colly.Response, scan *Scan, matches *scanner.Results) {
+	document, ok := ExtractDocument(r.Body)
+	if !ok {
+		return
+	}
+
+	target := r.Request.URL.String()
+
+	if scan.InfoFlag {
+		matches.Infos = append(matches.Infos, MetadataMatch(target, document)...)
+		matches.Infos = append(matches.Infos, withoutInfoRegions(huntInfos(target, document.Text))...)
+		matches.Infos = scanner.RemoveDuplicateInfos(matches.Infos)
+	}
+
+	if scan.SecretsFlag {
+		secrets := huntSecrets(target, document.Text, &scan.SecretsSlice)
+		matches.Secrets = append(matches.Secrets, withoutSecretRegions(secrets)...)
+		matches.Secrets = scanner.RemoveDuplicateSecrets(matches.Secrets)
+	}
+}
+
+// MetadataMatch returns the metadata of a document as infos.
+func MetadataMatch(target string, document scanner.Document) []scanner.InfoMatched {
+	infos := []scanner.InfoMatched{}
+
+	for _, metadata := range document.Metadata {
+		infos = append(infos, scanner.InfoMatched{Info: scanner.Info{Name: metadata.Name}, URL: target,
+			Match: metadata.Value})
+	}
+
+	return infos
+}
+
+// ExtractDocument extracts the metadata (authors, usernames, software,
+// internal paths, printers...) and the text of a PDF or OOXML (docx,
+// xlsx, pptx) document. It returns false if the body is not a document.
+func ExtractDocument(body []byte) (scanner.Document, bool) {
+	head := body
+	if len(head) > pdfHeaderOffset {
+		head = head[:pdfHeaderOffset]
+	}
+
+	switch {
+	case bytes.Contains(head, []byte("%PDF-")):
+		return extractPDF(body), true
+	case bytes.HasPrefix(body, []byte("PK\x03\x04")):
+		return extractOOXML(body)
+	}
+
+	return scanner.Document{}, false
+}
+
+// extractPDF extracts the info dictionary, the XMP metadata and the
+// text of a PDF, inflating the compressed streams.
+func extractPDF(body []byte) scanner.Document {
+	sections := [][]byte{body}
+	text := []string{}
+
+	for _, loc := range pdfStreamRegex.FindAllIndex(body, maxPDFStreams) {
+		if loc[0] >= len("end") && string(body[loc[0]-len("end"):loc[0]]) == "end" {
+			continue
+		}
+
+		end := bytes.Index(body[loc[1]:], []byte("endstream"))
+		if end < 0 {
+			continue
+		}
+
+		decoded := inflate(body[loc[1] : loc[1]+end])
+		if len(decoded) == 0 {
+			continue
+		}
+
+		sections = append(sections, decoded)
+
+		if bytes.Contains(decoded, []byte("BT")) {
+			text = append(text, pdfText(decoded)...)
+		}
+	}
+
+	metadata := []scanner.Metadata{}
+	extra := []string{}
+
+	for _, section := range sections {
+		for _, match := range pdfInfoRegex.FindAllSubmatchIndex(section, -1) {
+			key := string(section[match[2]:match[3]])
+			value := strings.TrimSpace(parsePDFString(section[match[1]-1:]))
+
+			if name, ok := scanner.GetPDFInfoKeys()[key]; ok {
+				metadata = appendMetadata(metadata, name, value)
+			} else {
+				extra = append(extra, value)
+			}
+		}
+
+		for tag, name := range scanner.GetXMPTags() {
+			for _, value := range xmlValues(section, tag) {
+				metadata = appendMetadata(metadata, name, value)
+			}
+		}
+	}
+
+	return newDocument(scanner.DocumentPDF, metadata, append(extra, text...))
+}
+
+// extractOOXML extracts the document properties, the external
+// targets, the printer settings and the text of an OOXML document.
+func extractOOXML(body []byte) (scanner.Document, bool) {
+	reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil || !zipContains(reader, "[Content_Types].xml") {
+		return scanner.Document{}, false
+	}
+
+	metadata := []scanner.Metadata{}
+	text := []string{}
+
+	for i, file := range reader.File {
+		if i >= maxDocumentEntries {
+			break
+		}
+
+		data, err := readZipFile(file)
+		if err != nil {
+			continue
+		}
+
+		switch name := file.Name; {
+		case name == "docProps/core.xml" || name == "docProps/app.xml":
+			for tag, metadataName := range scanner.GetOOXMLProperties() {
+				for _, value := range xmlValues(data, tag) {
+					metadata = appendMetadata(metadata, metadataName, value)
+				}
+			}
+
+			if application := xmlValues(data, "Application"); len(application) != 0 {
+				metadata = appendMetadata(metadata, scanner.MetadataSoftware,
+					strings.TrimSpace(application[0]+" "+strings.Join(xmlValues(data, "AppVersion"), "")))
+			}
+
+			text = append(text, xmlValues(data, "Template")...)
+		case strings.HasSuffix(name, ".rels"):
+			for _, match := range relsTargetRegex.FindAllSubmatch(data, -1) {
+				metadata = appendMetadata(metadata, scanner.MetadataPath, html.UnescapeString(string(match[1])))
+			}
+		case name == "xl/workbook.xml":
+			for _, match := range absPathRegex.FindAllSubmatch(data, -1) {
+				metadata = appendMetadata(metadata, scanner.MetadataPath, html.UnescapeString(string(match[1])))
+			}
+		case strings.Contains(name, "printerSettings/") && strings.HasSuffix(name, ".bin"):
+			metadata = appendMetadata(metadata, scanner.MetadataPrinter, devModeName(data))
+		case ooxmlTextEntries.MatchString(name):
+			for _, match := range ooxmlAuthorRegex.FindAllSubmatch(data, -1) {
+				metadata = appendMetadata(metadata, scanner.MetadataAuthor,
+					html.UnescapeString(string(match[1])+string(match[2])))
+			}
+
+			text = append(text, xmlText(data))
+		}
+	}
+
+	return newDocument(scanner.DocumentOOXML, metadata, text), true
+}
+
+// newDocument builds a document, adding the internal paths and the
+// usernames found in the metadata and in the text.
+func newDocument(format string, metadata []scanner.Metadata, text []string) scanner.Document {
+	values := []string{}
+	for _, elem := range metadata {
+		values = append(values, elem.Value)
+	}
+
+	allText := strings.Join(append(values, text...), "\n")
+
+	for _, path := range windowsPathRegex.FindAllString(allText, -1) {
+		metadata = appendMetadata(metadata, scanner.MetadataPath, path)
+	}
+
+	for _, match := range unixPathRegex.FindAllStringSubmatch(allText, -1) {
+		metadata = appendMetadata(metadata, scanner.MetadataPath, match[1])
+	}
+
+	for _, elem := range metadata {
+		if elem.Name != scanner.MetadataPath {
+			continue
+		}
+
+		for _, match := range pathUsernameRegex.FindAllStringSubmatch(elem.Value, -1) {
+			if !ignoredUsername(match[1]) {
+				metadata = appendMetadata(metadata, scanner.MetadataUsername, match[1])
+			}
+		}
+	}
+
+	return scanner.Document{Format: format, Metadata: metadata, Text: allText}
+}
+
+// appendMetadata appends a metadata if the value is not empty
+// and it's not already present.
+func appendMetadata(metadata []scanner.Metadata, name, value string) []scanner.Metadata {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return metadata
+	}
+
+	for _, elem := range metadata {
+		if elem.Name == name && elem.Value == value {
+			return metadata
+		}
+	}
+
+	return append(metadata, scanner.Metadata{Name: name, Value: value})
+}
+
+// ignoredUsername checks if a path component after
+// Users or home is not a real user.
+func ignoredUsername(username string) bool {
+	for _, ignored := range ignoredUsernames {
+		if strings.EqualFold(username, ignored) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inflate decompresses a Flate (zlib) stream. It returns nil
+// if the stream is not compressed.
+func inflate(data []byte) []byte {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+
+	// Streams are often truncated or followed by garbage:
+	// keep what was decompressed.
+	decoded, _ := io.ReadAll(io.LimitReader(reader, maxDocumentEntrySize))
+
+	return decoded
+}
+
+// pdfText extracts the strings shown by the text
+// operators (Tj, TJ, ' and ") of a content stream.
+func pdfText(stream []byte) []string {
+	text := []string{}
+
+	for _, match := range pdfTextRegex.FindAllSubmatch(stream, -1) {
+		if match[2] != nil {
+			text = append(text, parsePDFString(match[2]))
+			continue
+		}
+
+		line := ""
+		for _, literal := range pdfLiteralRegex.FindAll(match[1], -1) {
+			line += parsePDFString(literal)
+		}
+
+		text = append(text, line)
+	}
+
+	return text
+}
+
+// parsePDFString parses the literal ((...)) or hexadecimal (<...>)
+// string at the beginning of data and decodes it.
+func parsePDFString(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	if data[0] == '<' {
+		end := bytes.IndexByte(data, '>')
+		if end < 0 {
+			return ""
+		}
+
+		digits := strings.Join(strings.Fields(string(data[1:end])), "")
+		if len(digits)%2 == 1 {
+			digits += "0"
+		}
+
+		decoded, err := hex.DecodeString(digits)
+		if err != nil {
+			return ""
+		}
+
+		return decodePDFText(decoded)
+	}
+
+	return decodePDFText(pdfLiteral(data))
+}
+
+// pdfLiteral unescapes the literal string at the beginning of data.
+func pdfLiteral(data []byte) []byte {
+	result := []byte{}
+	depth := 0
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		switch {
+		case c == '(':
+			depth++
+			if depth == 1 {
+				continue
+			}
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return result
+			}
+		case c == '\\' && i+1 < len(data):
+			i++
+			c = data[i]
+
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					end := i + 1
+					for end < len(data) && end < i+3 && data[end] >= '0' && data[end] <= '7' {
+						end++
+					}
+
+					value, _ := strconv.ParseUint(string(data[i:end]), 8, 8)
+					c = byte(value)
+					i = end - 1
+				}
+			}
+		}
+
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// decodePDFText decodes a PDF text string: UTF-16BE (with
+// byte order mark), UTF-8 (with byte order mark) or PDFDocEncoding.
+func decodePDFText(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\xfe\xff")):
+		return decodeUTF16(data[2:], true)
+	case bytes.HasPrefix(data, []byte("\xef\xbb\xbf")):
+		return string(data[3:])
+	}
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
+
+// decodeUTF16 decodes an UTF-16 string (big or little endian).
+func decodeUTF16(data []byte, bigEndian bool) string {
+	units := make([]uint16, len(data)/2)
+
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+
+	return string(utf16.Decode(units))
+}
+
+// devModeName returns the device name of a Windows DEVMODE structure
+// (the printer settings stored by Office).
+func devModeName(data []byte) string {
+	if len(data) < devModeNameLength {
+		return ""
+	}
+
+	name := decodeUTF16(data[:devModeNameLength], false)
+	if i := strings.IndexRune(name, 0); i > -1 {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// xmlValues returns the values of an XML element (or attribute). The
+// items of RDF containers (XMP) are returned one by one.
+func xmlValues(data []byte, tag string) []string {
+	regexes, ok := xmlValueRegexes[tag]
+	if !ok {
+		regexes = compileXMLValueRegex(tag)
+	}
+
+	values := []string{}
+
+	for _, match := range regexes.element.FindAllSubmatch(data, -1) {
+		if items := rdfItemRegex.FindAllSubmatch(match[1], -1); len(items) != 0 {
+			for _, item := range items {
+				values = append(values, html.UnescapeString(string(item[1])))
+			}
+
+			continue
+		}
+
+		values = append(values, xmlText(match[1]))
+	}
+
+	for _, match := range regexes.attribute.FindAllSubmatch(data, -1) {
+		values = append(values, html.UnescapeString(string(match[1])))
+	}
+
+	return values
+}
+
+// xmlText returns the text of an XML document, without the tags.
+func xmlText(data []byte) string {
+	return strings.TrimSpace(html.UnescapeString(xmlTagRegex.ReplaceAllString(string(data), " ")))
+}
+
+// zipContains checks if a ZIP archive contains a file.
+func zipContains(reader *zip.Reader, name string) bool {
+	for _, file := range reader.File {
+		if file.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readZipFile reads a file of a ZIP archive, refusing
+// the ones bigger than maxDocumentEntrySize.
+func readZipFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > maxDocumentEntrySize {
+		return nil, ErrEntryTooBig
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(io.LimitReader(reader, maxDocumentEntrySize))
+}
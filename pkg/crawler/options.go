@@ -26,16 +26,12 @@ along with this program.  If not, see http://www.gnu.org/licenses/.
 
 package crawler
 
-import "github.com/edoardottt/cariddi/pkg/scanner"
+import (
+	"sync"
 
-type Results struct {
-	URLs       []string
-	Secrets    []scanner.SecretMatched
-	Endpoints  []scanner.EndpointMatched
-	Extensions []scanner.FileTypeMatched
-	Errors     []scanner.ErrorMatched
-	Infos      []scanner.InfoMatched
-}
+	"github.com/edoardottt/cariddi/pkg/output"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
 
 type Scan struct {
 	// Flags
@@ -51,14 +47,33 @@ type Scan struct {
 	Ignore        string
 	IgnoreTxt     string
 	JSON          bool
-	HTML          string
 	Proxy         string
 	Target        string
-	Txt           string
 	UserAgent     string
 	FileType      int
 	Headers       map[string]string
 	StoreResp     bool
+	Redact        bool
+	RedactHash    bool
+	DecodeDepth   int
+	SecHeaders    bool
+	CookiesFlag   bool
+	CORSFlag      bool
+	TechFlag      bool
+	TechFile      string
+	JSLibsFlag    bool
+	JSLibsFile    string
+	HostsFlag     bool
+	CloudFlag     bool
+	CloudCheck    bool
+	CloudEndpoint string
+	APIFlag       bool
+	SpecFile      string
+	XSSFlag       bool
+	RedirFlag     bool
+	RedirURL      string
+	BackupFlag    bool
+	MinSeverity   string
 
 	// Settings
 	Concurrency int
@@ -68,6 +83,11 @@ type Scan struct {
 	// Storage
 	SecretsSlice   []string
 	EndpointsSlice []string
+	PathsSlice     []string
+	Baseline       *scanner.Baseline
+
+	// Output
+	Writers *output.Writers
 }
 
 type Event struct {
@@ -80,4 +100,6 @@ type Event struct {
 	JSON         bool
 	IgnoreSlice  []string
 	URLs         *[]string
+	// Mutex guards the results shared by the concurrent callbacks.
+	Mutex *sync.Mutex
 }
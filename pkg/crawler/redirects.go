@@ -0,0 +1,188 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+	"github.com/gocolly/colly"
+)
+
+// requestedURLKey is the context key of the URL requested
+// (before following the redirects).
+const requestedURLKey = "requested-url"
+
+// nolint: gochecknoglobals
+var (
+	metaRefreshRegex = regexp.MustCompile(`(?is)http-equiv\s*=\s*["']?refresh`)
+	metaURLRegex     = regexp.MustCompile(`(?is)content\s*=\s*["']?[^"'>]*?url\s*=\s*['"]?([^"'>\s;]+)`)
+	jsRedirectRegex  = regexp.MustCompile(`(?i)(?:location(?:\.href)?\s*=|location\.(?:replace|assign)\s*\()\s*` +
+		"[\"'`]([^\"'`]+)")
+)
+
+// redirectParameters returns the parameters of an endpoint that may
+// cause a redirect: the ones labeled as OpenRedir and the ones holding a URL.
+func redirectParameters(u *url.URL) []string {
+	redirectLike := map[string]bool{}
+
+	for _, parameter := range scanner.GetJuicyParameters() {
+		for _, attack := range parameter.Attacks {
+			if attack == "OpenRedir" {
+				redirectLike[parameter.Parameter] = true
+			}
+		}
+	}
+
+	parameters := []string{}
+	values := u.Query()
+
+	for _, parameter := range parameterNames(u) {
+		if redirectLike[strings.ToLower(parameter)] || ClassifyValue(values.Get(parameter)) == scanner.ValueURL {
+			parameters = append(parameters, parameter)
+		}
+	}
+
+	return parameters
+}
+
+// requestedURL returns the URL requested by the crawler, before
+// following the redirects (the response URL if it's unknown).
+func requestedURL(r *colly.Response) string {
+	if r.Ctx != nil {
+		if requested := r.Ctx.Get(requestedURLKey); requested != "" {
+			return requested
+		}
+	}
+
+	return r.Request.URL.String()
+}
+
+// redirectCandidate checks if an endpoint has redirect-like parameters.
+func redirectCandidate(target string) bool {
+	u, err := url.Parse(target)
+	return err == nil && len(redirectParameters(u)) != 0
+}
+
+// huntRedirects injects a controlled external URL in the redirect-like
+// parameters of an endpoint and returns the confirmed open redirects.
+// Redirects are not followed.
+func huntRedirects(client *activeClient, target, controlled string) []scanner.RedirectMatched {
+	results := []scanner.RedirectMatched{}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return results
+	}
+
+	controlledURL, err := url.Parse(controlled)
+	if err != nil || controlledURL.Host == "" {
+		return results
+	}
+
+	for _, parameter := range redirectParameters(u) {
+		for _, payload := range scanner.GetRedirectPayloads(controlled, controlledURL.Host) {
+			injected := injectParameter(u, parameter, payload)
+
+			resp, body, err := client.Do(http.MethodGet, injected, nil)
+			if err != nil {
+				continue
+			}
+
+			method, location, ok := RedirectMatch(injected, resp.StatusCode, resp.Header.Get("Location"),
+				string(body), controlledURL.Host)
+			if !ok {
+				continue
+			}
+
+			redirect := scanner.Redirect{
+				Parameter: parameter,
+				Payload:   payload,
+				Method:    method,
+				Location:  location,
+				Severity:  scanner.SeverityMedium,
+			}
+			results = append(results, scanner.RedirectMatched{Redirect: redirect, URL: target})
+
+			break
+		}
+	}
+
+	return results
+}
+
+// RedirectMatch checks if a response redirects to the controlled host
+// through the Location header (3xx responses), a meta refresh tag or
+// JavaScript. It returns the method and the redirect target.
+func RedirectMatch(target string, statusCode int, location, body, controlledHost string) (string, string, bool) {
+	if statusCode >= http.StatusMultipleChoices && statusCode < http.StatusBadRequest &&
+		redirectsTo(target, location, controlledHost) {
+		return scanner.RedirectHeader, location, true
+	}
+
+	for _, tag := range metaTagRegex.FindAllString(body, -1) {
+		if !metaRefreshRegex.MatchString(tag) {
+			continue
+		}
+
+		if match := metaURLRegex.FindStringSubmatch(tag); match != nil && redirectsTo(target, match[1], controlledHost) {
+			return scanner.RedirectMeta, match[1], true
+		}
+	}
+
+	for _, match := range jsRedirectRegex.FindAllStringSubmatch(body, -1) {
+		if redirectsTo(target, match[1], controlledHost) {
+			return scanner.RedirectJavaScript, match[1], true
+		}
+	}
+
+	return "", "", false
+}
+
+// redirectsTo checks if a redirect target (resolved
+// against the endpoint) points to the controlled host.
+func redirectsTo(target, location, controlledHost string) bool {
+	if location == "" {
+		return false
+	}
+
+	base, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	// Browsers treat backslashes as slashes (e.g. /\evil.com).
+	resolved, err := base.Parse(strings.ReplaceAll(strings.TrimSpace(location), `\`, "/"))
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(resolved.Host, controlledHost)
+}
@@ -0,0 +1,167 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/output"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestRedirectMatch(t *testing.T) {
+	const target = "https://example.com/login?next=https://cariddi.test/"
+
+	tests := []struct {
+		name       string
+		statusCode int
+		location   string
+		body       string
+		wantMethod string
+		wantOk     bool
+	}{
+		{
+			name:       "location header",
+			statusCode: http.StatusFound,
+			location:   "https://cariddi.test/",
+			wantMethod: scanner.RedirectHeader,
+			wantOk:     true,
+		},
+		{
+			name:       "scheme relative location",
+			statusCode: http.StatusMovedPermanently,
+			location:   "//cariddi.test/",
+			wantMethod: scanner.RedirectHeader,
+			wantOk:     true,
+		},
+		{
+			name:       "backslash location",
+			statusCode: http.StatusFound,
+			location:   `/\cariddi.test/`,
+			wantMethod: scanner.RedirectHeader,
+			wantOk:     true,
+		},
+		{
+			name:       "location on the same host",
+			statusCode: http.StatusFound,
+			location:   "/home?from=https://cariddi.test/",
+		},
+		{
+			name:       "location without redirect status",
+			statusCode: http.StatusOK,
+			location:   "https://cariddi.test/",
+		},
+		{
+			name:       "meta refresh",
+			statusCode: http.StatusOK,
+			body:       `<meta http-equiv="refresh" content="0; url=https://cariddi.test/">`,
+			wantMethod: scanner.RedirectMeta,
+			wantOk:     true,
+		},
+		{
+			name:       "javascript",
+			statusCode: http.StatusOK,
+			body:       `<script>window.location.href = "https://cariddi.test/";</script>`,
+			wantMethod: scanner.RedirectJavaScript,
+			wantOk:     true,
+		},
+		{
+			name:       "javascript replace",
+			statusCode: http.StatusOK,
+			body:       `<script>location.replace('//cariddi.test/')</script>`,
+			wantMethod: scanner.RedirectJavaScript,
+			wantOk:     true,
+		},
+		{
+			name:       "controlled url only displayed",
+			statusCode: http.StatusOK,
+			body:       `<a href="https://cariddi.test/">continue</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, _, ok := crawler.RedirectMatch(target, tt.statusCode, tt.location, tt.body, "cariddi.test")
+			if method != tt.wantMethod || ok != tt.wantOk {
+				t.Errorf("RedirectMatch() = %q, %v, want %q, %v", method, ok, tt.wantMethod, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><a href="/login?next=/home">login</a><a href="/safe?redirect=/home">safe</a>` +
+			`<a href="/go?u=https://partner.com/">partner</a></html>`))
+	})
+	mux.HandleFunc("/home", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html>home</html>`))
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Query().Get("next"), http.StatusFound)
+	})
+	mux.HandleFunc("/safe", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/home", http.StatusFound)
+	})
+	mux.HandleFunc("/go", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<meta http-equiv="refresh" content="1;url=` + r.URL.Query().Get("u") + `">`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	results := crawler.New(&crawler.Scan{
+		Target:      server.URL,
+		Concurrency: 1,
+		Timeout:     5,
+		JSON:        true,
+		RedirFlag:   true,
+		RedirURL:    "https://cariddi.test/",
+	})
+
+	got := map[string]bool{}
+	for _, elem := range scanner.RemoveDuplicateRedirects(results.Redirects) {
+		got[output.FormatRedirect(elem)] = true
+	}
+
+	want := map[string]bool{
+		"next - Location header -> https://cariddi.test/ (medium)": true,
+		"u - meta refresh -> https://cariddi.test/ (medium)":       true,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Redirects\n%v", got)
+		t.Errorf("want\n%v", want)
+	}
+}
@@ -28,9 +28,18 @@ package crawler
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// maxActiveBodySize is the maximum number of bytes read
+	// from the body of an active check response.
+	maxActiveBodySize = 1024 * 1024
 )
 
 // GetRequest performs a GET request and return
@@ -98,3 +107,100 @@ func HeadRequest(target string) (string, error) {
 
 	return sb, nil
 }
+
+// activeClient performs the requests of the active checks
+// (e.g. CORS), honoring the timeout, the proxy, the delay,
+// the user agent and the custom headers of the scan.
+// Redirects are never followed.
+// The requests are sent from the crawler callbacks, that are not
+// limited by the collector: at most slots requests are sent at a time.
+type activeClient struct {
+	client    *http.Client
+	headers   map[string]string
+	userAgent string
+	delay     time.Duration
+	slots     chan struct{}
+}
+
+// newActiveClient returns an activeClient built
+// from the scan settings.
+func newActiveClient(scan *Scan, userAgent string) *activeClient {
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+
+	if scan.Proxy != "" {
+		if proxyURL, err := url.Parse(scan.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	concurrency := scan.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &activeClient{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(scan.Timeout) * time.Second,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		headers:   scan.Headers,
+		userAgent: userAgent,
+		delay:     time.Duration(scan.Delay) * time.Second,
+		slots:     make(chan struct{}, concurrency),
+	}
+}
+
+// Do performs a request adding the extra headers and returns the
+// response along with its body (at most maxActiveBodySize bytes).
+func (a *activeClient) Do(method, target string, extra map[string]string) (*http.Response, []byte, error) {
+	return a.DoBody(method, target, nil, extra)
+}
+
+// DoBody is like Do, but it sends a request body.
+func (a *activeClient) DoBody(method, target string, data []byte,
+	extra map[string]string) (*http.Response, []byte, error) {
+	// The delay is applied while holding the slot,
+	// in this way it limits the request rate.
+	a.slots <- struct{}{}
+	defer func() { <-a.slots }()
+
+	time.Sleep(a.delay)
+
+	var reqBody io.Reader
+	if data != nil {
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, target, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Set("User-Agent", a.userAgent)
+
+	for header, value := range a.headers {
+		req.Header.Set(header, value)
+	}
+
+	for header, value := range extra {
+		req.Header.Set(header, value)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxActiveBodySize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, body, nil
+}
@@ -27,11 +27,40 @@ along with this program.  If not, see http://www.gnu.org/licenses/.
 package crawler
 
 import (
+	"encoding/json"
+	"html"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
+	decodeUtils "github.com/edoardottt/cariddi/internal/decode"
 	urlUtils "github.com/edoardottt/cariddi/internal/url"
 	"github.com/edoardottt/cariddi/pkg/scanner"
+	"github.com/gocolly/colly"
+)
+
+const (
+	// jwtLongLived is the lifetime after which a JWT is
+	// considered long-lived.
+	jwtLongLived = 365 * 24 * time.Hour
+	// minReflectedLength is the minimum length of a parameter
+	// value checked for reflection (shorter values are too common).
+	minReflectedLength = 4
+	// minBase64Length is the minimum length of a parameter
+	// value considered base64 encoded.
+	minBase64Length = 8
+)
+
+// nolint: gochecknoglobals
+var (
+	urlValueRegex    = regexp.MustCompile(`(?i)^(?:(?:[a-z][a-z0-9+.-]*:)?//[^/\s]+|(?:javascript|data|mailto):)`)
+	emailValueRegex  = regexp.MustCompile(`(?i)^[^@\s]+@[^@\s]+\.[a-z]{2,}$`)
+	numberValueRegex = regexp.MustCompile(`^-?[0-9]+(?:\.[0-9]+)?$`)
+	pathValueRegex   = regexp.MustCompile(`(?i)^(?:/|\./|~/|[a-z]:\\)|\.\.[/\\]|^[\w.-]+/[\w./-]+$|` +
+		`^[\w-]+\.(?:php|html?|txt|xml|json|ya?ml|conf|cfg|ini|log|pdf|jsp|aspx?)$`)
+	base64ValueRegex = regexp.MustCompile(`^[A-Za-z0-9+/_-]+={0,2}$`)
 )
 
 // huntSecrets hunts for secrets.
@@ -48,21 +77,28 @@ func SecretsMatch(url, body string, secretsFile *[]string) []scanner.SecretMatch
 		for _, secret := range scanner.GetSecretRegexes() {
 			if matched, err := regexp.Match(secret.Regex, []byte(body)); err == nil && matched {
 				re := regexp.MustCompile(secret.Regex)
-				matches := re.FindAllStringSubmatch(body, -1)
+				matches := re.FindAllStringIndex(body, -1)
 
 				// Avoiding false positives
 				var isFalsePositive = false
 
 				for _, match := range matches {
+					text := body[match[0]:match[1]]
+
 					for _, falsePositive := range secret.FalsePositives {
-						if strings.Contains(strings.ToLower(match[0]), falsePositive) {
+						if strings.Contains(strings.ToLower(text), falsePositive) {
 							isFalsePositive = true
 							break
 						}
 					}
 
 					if !isFalsePositive {
-						secretFound := scanner.SecretMatched{Secret: secret, URL: url, Match: match[0]}
+						secretFound := scanner.SecretMatched{
+							Secret: secret,
+							URL:    url,
+							Match:  text,
+							Region: scanner.NewRegion(body, match[0], match[1]),
+						}
 						secrets = append(secrets, secretFound)
 					}
 				}
@@ -72,11 +108,16 @@ func SecretsMatch(url, body string, secretsFile *[]string) []scanner.SecretMatch
 		for _, secret := range *secretsFile {
 			if matched, err := regexp.Match(secret, []byte(body)); err == nil && matched {
 				re := regexp.MustCompile(secret)
-				matches := re.FindAllStringSubmatch(body, -1)
+				matches := re.FindAllStringIndex(body, -1)
 
 				for _, match := range matches {
 					secretScanned := scanner.Secret{Name: "CustomFromFile", Description: "", Regex: secret, Poc: ""}
-					secretFound := scanner.SecretMatched{Secret: secretScanned, URL: url, Match: match[0]}
+					secretFound := scanner.SecretMatched{
+						Secret: secretScanned,
+						URL:    url,
+						Match:  body[match[0]:match[1]],
+						Region: scanner.NewRegion(body, match[0], match[1]),
+					}
 					secrets = append(secrets, secretFound)
 				}
 			}
@@ -86,23 +127,33 @@ func SecretsMatch(url, body string, secretsFile *[]string) []scanner.SecretMatch
 	return scanner.RemoveDuplicateSecrets(secrets)
 }
 
-// huntEndpoints hunts for juicy endpoints.
-func huntEndpoints(target string, endpointsFile *[]string) []scanner.EndpointMatched {
-	endpoints := EndpointsMatch(target, endpointsFile)
+// huntEndpoints hunts for juicy endpoints (parameters and paths).
+func huntEndpoints(target, body string, endpointsFile, pathsFile *[]string) []scanner.EndpointMatched {
+	endpoints := EndpointsMatch(target, body, endpointsFile)
+	paths := PathsMatch(target, pathsFile)
+
+	for i := range endpoints {
+		endpoints[i].Paths = paths
+	}
+
 	return endpoints
 }
 
 // EndpointsMatch check if an endpoint matches a juicy parameter.
-func EndpointsMatch(target string, endpointsFile *[]string) []scanner.EndpointMatched {
+// The values of the parameters matched are classified and checked
+// for reflection in the response body (if any).
+func EndpointsMatch(target, body string, endpointsFile *[]string) []scanner.EndpointMatched {
 	endpoints := []scanner.EndpointMatched{}
 	matched := []scanner.Parameter{}
 	parameters := urlUtils.RetrieveParameters(target)
+	values := parameterValues(target)
+	body = removeQuery(target, body)
 
 	if len(*endpointsFile) == 0 {
 		for _, parameter := range scanner.GetJuicyParameters() {
 			for _, param := range parameters {
 				if strings.ToLower(param) == parameter.Parameter {
-					matched = append(matched, parameter)
+					matched = append(matched, analyzeParameter(parameter, target, body, values.Get(param)))
 				}
 			}
 		}
@@ -111,7 +162,8 @@ func EndpointsMatch(target string, endpointsFile *[]string) []scanner.EndpointMa
 		for _, parameter := range *endpointsFile {
 			for _, param := range parameters {
 				if param == parameter {
-					matched = append(matched, scanner.Parameter{Parameter: parameter, Attacks: []string{}})
+					matched = append(matched, analyzeParameter(scanner.Parameter{Parameter: parameter, Attacks: []string{}},
+						target, body, values.Get(param)))
 				}
 			}
 		}
@@ -121,25 +173,131 @@ func EndpointsMatch(target string, endpointsFile *[]string) []scanner.EndpointMa
 	return endpoints
 }
 
-// huntExtensions hunts for extensions.
-func huntExtensions(target string, severity int) scanner.FileTypeMatched {
-	extension := scanner.FileTypeMatched{}
-	copyTarget := target
+// analyzeParameter classifies the value of a parameter, checks if it's
+// reflected in the body and sets the confidence of the attacks suggested.
+func analyzeParameter(parameter scanner.Parameter, target, body, value string) scanner.Parameter {
+	parameter.Value = value
+	parameter.ValueType = ClassifyValue(value)
+	parameter.OffSite = parameter.ValueType == scanner.ValueURL && offSite(target, value)
+	parameter.Reflected = len(value) >= minReflectedLength && strings.Contains(body, value)
 
-	for _, ext := range scanner.GetExtensions() {
-		if ext.Severity <= severity {
-			firstIndex := strings.Index(target, "?")
-			if firstIndex > -1 {
-				target = target[:firstIndex]
-			}
+	if len(parameter.Attacks) != 0 {
+		parameter.Confidence = scanner.ParameterConfidence(parameter)
+	}
+
+	return parameter
+}
+
+// ClassifyValue returns the kind of a parameter value (url, path,
+// number, email, base64 or json). It returns an empty string
+// if the value doesn't match any of them.
+func ClassifyValue(value string) string {
+	switch {
+	case value == "":
+		return ""
+	case (strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[")) && json.Valid([]byte(value)):
+		return scanner.ValueJSON
+	case urlValueRegex.MatchString(value):
+		return scanner.ValueURL
+	case emailValueRegex.MatchString(value):
+		return scanner.ValueEmail
+	case numberValueRegex.MatchString(value):
+		return scanner.ValueNumber
+	case pathValueRegex.MatchString(value):
+		return scanner.ValuePath
+	case len(value) >= minBase64Length && base64ValueRegex.MatchString(value):
+		if _, ok := decodeUtils.DecodeBase64(value); ok {
+			return scanner.ValueBase64
+		}
+	}
+
+	return ""
+}
+
+// parameterValues returns the values of the query parameters of a URL.
+func parameterValues(target string) url.Values {
+	u, err := url.Parse(target)
+	if err != nil {
+		return url.Values{}
+	}
+
+	values, _ := url.ParseQuery(u.RawQuery)
+
+	return values
+}
+
+// removeQuery removes the query of the URL from the body, so that
+// links to the page itself are not considered reflections.
+func removeQuery(target, body string) string {
+	u, err := url.Parse(target)
+	if err != nil || u.RawQuery == "" {
+		return body
+	}
 
-			if strings.ToLower(target[len(target)-len("."+ext.Extension):]) == "."+ext.Extension {
-				extension = scanner.FileTypeMatched{Filetype: ext, URL: copyTarget}
+	return strings.NewReplacer(u.RawQuery, "", html.EscapeString(u.RawQuery), "").Replace(body)
+}
+
+// offSite checks if a URL value points to a host different from the target's one.
+func offSite(target, value string) bool {
+	if strings.HasPrefix(value, "//") {
+		value = "http:" + value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	t, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	return !strings.EqualFold(urlUtils.RemovePort(u.Host), urlUtils.RemovePort(t.Host))
+}
+
+// PathsMatch checks if the path of an endpoint matches a juicy path.
+func PathsMatch(target string, pathsFile *[]string) []scanner.JuicyPath {
+	paths := []scanner.JuicyPath{}
+
+	path, err := urlUtils.GetPath(target)
+	if err != nil || path == "" {
+		return paths
+	}
+
+	if len(*pathsFile) == 0 {
+		for _, juicyPath := range scanner.GetJuicyPaths() {
+			if matched, err := regexp.MatchString(juicyPath.Regex, path); err == nil && matched {
+				paths = append(paths, juicyPath)
+			}
+		}
+	} else {
+		for _, regex := range *pathsFile {
+			if matched, err := regexp.MatchString(regex, path); err == nil && matched {
+				paths = append(paths, scanner.JuicyPath{Name: "CustomFromFile", Regex: regex,
+					Category: "Custom", Severity: scanner.SeverityInfo})
 			}
 		}
 	}
 
-	return extension
+	return paths
+}
+
+// huntExtensions hunts for extensions, classifying the
+// file served by the response headers, the body and the URL.
+// The content of the files is validated when possible (see ValidContent).
+func huntExtensions(target string, headers *http.Header, body []byte, severity int) scanner.FileTypeMatched {
+	responseHeaders := http.Header{}
+	if headers != nil {
+		responseHeaders = *headers
+	}
+
+	filetype, signal, ok := FileTypeMatch(target, responseHeaders, body)
+	if !ok || filetype.Severity > severity {
+		return scanner.FileTypeMatched{}
+	}
+
+	return scanner.FileTypeMatched{Filetype: filetype, URL: target, Signal: signal}
 }
 
 // huntErrors hunts for errors.
@@ -156,10 +314,15 @@ func ErrorsMatch(url, body string) []scanner.ErrorMatched {
 		for _, errorRegex := range errorItem.Regex {
 			if matched, err := regexp.Match(errorRegex, []byte(body)); err == nil && matched {
 				re := regexp.MustCompile(errorRegex)
-				matches := re.FindAllStringSubmatch(body, -1)
+				matches := re.FindAllStringIndex(body, -1)
 
 				for _, match := range matches {
-					errorFound := scanner.ErrorMatched{Error: errorItem, URL: url, Match: match[0]}
+					errorFound := scanner.ErrorMatched{
+						Error:  errorItem,
+						URL:    url,
+						Match:  body[match[0]:match[1]],
+						Region: scanner.NewRegion(body, match[0], match[1]),
+					}
 					errors = append(errors, errorFound)
 				}
 			}
@@ -182,10 +345,15 @@ func InfoMatch(url, body string) []scanner.InfoMatched {
 	for _, infoItem := range scanner.GetInfoRegexes() {
 		if matched, err := regexp.Match(infoItem.Regex, []byte(body)); err == nil && matched {
 			re := regexp.MustCompile(infoItem.Regex)
-			matches := re.FindAllStringSubmatch(body, -1)
+			matches := re.FindAllStringIndex(body, -1)
 
 			for _, match := range matches {
-				infoFound := scanner.InfoMatched{Info: infoItem, URL: url, Match: match[0]}
+				infoFound := scanner.InfoMatched{
+					Info:   infoItem,
+					URL:    url,
+					Match:  body[match[0]:match[1]],
+					Region: scanner.NewRegion(body, match[0], match[1]),
+				}
 				infos = append(infos, infoFound)
 			}
 		}
@@ -193,3 +361,161 @@ func InfoMatch(url, body string) []scanner.InfoMatched {
 
 	return scanner.RemoveDuplicateInfos(infos)
 }
+
+// huntDecoded decodes the encoded blobs found in the body, in the
+// query string and in the cookies set by a response and hunts for
+// JWTs, secrets and infos in the decoded content.
+// The request cookies are the ones supplied with the custom headers,
+// so they are not scanned.
+func huntDecoded(r *colly.Response, scan *Scan, matches *scanner.Results) {
+	target := r.Request.URL.String()
+	cookies := strings.Join(r.Headers.Values("Set-Cookie"), "\n")
+	sources := []struct {
+		name string
+		text string
+	}{
+		{scanner.SourceBody, string(r.Body)},
+		{scanner.SourceQuery, r.Request.URL.RawQuery},
+		{scanner.SourceCookie, cookies},
+	}
+
+	for _, source := range sources {
+		matches.JWTs = append(matches.JWTs, JWTsMatch(target, source.name, source.text)...)
+
+		// The blobs are returned depth first: the blobs found
+		// at depth 1 are the ones present in the source.
+		encoded := ""
+
+		for _, blob := range decodeUtils.FindBlobs(source.text, scan.DecodeDepth) {
+			if blob.Depth == 1 {
+				encoded = blob.Encoded
+			}
+
+			for _, jwt := range JWTsMatch(target, source.name, blob.Decoded) {
+				jwt.Encoded = encoded
+				matches.JWTs = append(matches.JWTs, jwt)
+			}
+
+			if scan.SecretsFlag {
+				for _, secret := range huntSecrets(target, blob.Decoded, &scan.SecretsSlice) {
+					secret.Encoded = encoded
+					secret.Region = nil
+					matches.Secrets = append(matches.Secrets, secret)
+				}
+			}
+
+			if scan.InfoFlag {
+				matches.Infos = append(matches.Infos, withoutInfoRegions(huntInfos(target, blob.Decoded))...)
+			}
+		}
+	}
+
+	matches.JWTs = scanner.RemoveDuplicateJWTs(matches.JWTs)
+	matches.Secrets = scanner.RemoveDuplicateSecrets(matches.Secrets)
+	matches.Infos = scanner.RemoveDuplicateInfos(matches.Infos)
+}
+
+// withoutSecretRegions removes the regions of the secrets found
+// in a text that is not the response body (e.g. a document text).
+func withoutSecretRegions(secrets []scanner.SecretMatched) []scanner.SecretMatched {
+	for i := range secrets {
+		secrets[i].Region = nil
+	}
+
+	return secrets
+}
+
+// withoutInfoRegions removes the regions of the infos found
+// in a text that is not the response body (e.g. a document text).
+func withoutInfoRegions(infos []scanner.InfoMatched) []scanner.InfoMatched {
+	for i := range infos {
+		infos[i].Region = nil
+	}
+
+	return infos
+}
+
+// JWTsMatch decodes the JSON Web Tokens found in a text.
+func JWTsMatch(url, source, text string) []scanner.JWTMatched {
+	jwts := []scanner.JWTMatched{}
+
+	for _, token := range decodeUtils.FindJWTs(text) {
+		jwt, err := JWTMatch(token)
+		if err != nil {
+			continue
+		}
+
+		jwts = append(jwts, scanner.JWTMatched{JWT: jwt, URL: url, Source: source, Match: token})
+	}
+
+	return jwts
+}
+
+// JWTMatch decodes a JSON Web Token and checks it for weaknesses
+// (unsigned, expired or long-lived tokens).
+func JWTMatch(token string) (scanner.JWT, error) {
+	header, claims, err := decodeUtils.ParseJWT(token)
+	if err != nil {
+		return scanner.JWT{}, err
+	}
+
+	jwt := scanner.JWT{
+		Algorithm: jwtString(header, "alg"),
+		Type:      jwtString(header, "typ"),
+		KeyID:     jwtString(header, "kid"),
+		Issuer:    jwtString(claims, "iss"),
+		Subject:   jwtString(claims, "sub"),
+		Audience:  jwtString(claims, "aud"),
+		ExpiresAt: jwtTime(claims, "exp"),
+		IssuedAt:  jwtTime(claims, "iat"),
+		Claims:    claims,
+	}
+
+	if jwt.Algorithm == "" || strings.EqualFold(jwt.Algorithm, "none") {
+		jwt.Issues = append(jwt.Issues, scanner.JWTAlgNone)
+	}
+
+	now := time.Now()
+
+	switch expiration := time.Unix(jwt.ExpiresAt, 0); {
+	case jwt.ExpiresAt == 0:
+		jwt.Issues = append(jwt.Issues, scanner.JWTNoExpiration)
+	case expiration.Before(now):
+		jwt.Issues = append(jwt.Issues, scanner.JWTExpired)
+	case expiration.Sub(now) > jwtLongLived ||
+		(jwt.IssuedAt != 0 && expiration.Sub(time.Unix(jwt.IssuedAt, 0)) > jwtLongLived):
+		jwt.Issues = append(jwt.Issues, scanner.JWTLongLived)
+	}
+
+	return jwt, nil
+}
+
+// jwtString returns a JWT field as a string.
+// Arrays (e.g. the audience) are joined with commas.
+func jwtString(fields map[string]interface{}, name string) string {
+	switch value := fields[name].(type) {
+	case string:
+		return value
+	case []interface{}:
+		values := []string{}
+
+		for _, elem := range value {
+			if str, ok := elem.(string); ok {
+				values = append(values, str)
+			}
+		}
+
+		return strings.Join(values, ",")
+	default:
+		return ""
+	}
+}
+
+// jwtTime returns a JWT NumericDate field as Unix time.
+func jwtTime(fields map[string]interface{}, name string) int64 {
+	if value, ok := fields[name].(float64); ok {
+		return int64(value)
+	}
+
+	return 0
+}
@@ -0,0 +1,413 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+const (
+	// techEvidenceLength is the maximum length of the
+	// body snippets reported as evidence.
+	techEvidenceLength = 100
+)
+
+// nolint: gochecknoglobals
+var (
+	metaTagRegex        = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	tagAttributeRegex   = regexp.MustCompile(`(?is)\b([\w-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	scriptSrcRegex      = regexp.MustCompile(`(?is)<script[^>]+\bsrc\s*=\s*["']?([^"'\s>]+)`)
+	versionGroupRegex   = regexp.MustCompile(`\\(\d+)`)
+	versionTernaryRegex = regexp.MustCompile(`^\\(\d+)\?([^:]*):(.*)$`)
+)
+
+// techPattern is a compiled Wappalyzer pattern.
+// Version is the version template (e.g. `\1`).
+type techPattern struct {
+	regex   *regexp.Regexp
+	version string
+}
+
+// techFingerprint is a compiled Wappalyzer fingerprint.
+type techFingerprint struct {
+	technology scanner.Technology
+	headers    map[string][]techPattern
+	cookies    map[string][]techPattern
+	meta       map[string][]techPattern
+	html       []techPattern
+	scriptSrc  []techPattern
+	scripts    []techPattern
+	url        []techPattern
+	implies    []string
+}
+
+// Technologies is a compiled fingerprints database.
+type Technologies struct {
+	fingerprints []techFingerprint
+	index        map[string]int
+}
+
+// NewTechnologies compiles a fingerprints database.
+// Patterns not supported by the Go regexp syntax are skipped.
+func NewTechnologies(fingerprints *scanner.Fingerprints) *Technologies {
+	names := make([]string, 0, len(fingerprints.Technologies))
+	for name := range fingerprints.Technologies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	technologies := &Technologies{index: make(map[string]int)}
+
+	for _, name := range names {
+		fingerprint := fingerprints.Technologies[name]
+		technology := scanner.Technology{Name: name, Website: fingerprint.Website}
+
+		for _, cat := range fingerprint.Cats {
+			if category, ok := fingerprints.Categories[strconv.Itoa(cat)]; ok {
+				technology.Categories = append(technology.Categories, category.Name)
+			}
+		}
+
+		compiled := techFingerprint{
+			technology: technology,
+			headers:    compilePatternsMap(fingerprint.Headers),
+			cookies:    compilePatternsMap(fingerprint.Cookies),
+			meta:       compilePatternsMap(fingerprint.Meta),
+			html:       compilePatterns(fingerprint.HTML),
+			scriptSrc:  compilePatterns(fingerprint.ScriptSrc),
+			scripts:    compilePatterns(fingerprint.Scripts),
+			url:        compilePatterns(fingerprint.URL),
+		}
+
+		for _, implied := range fingerprint.Implies {
+			compiled.implies = append(compiled.implies, strings.Split(implied, `\;`)[0])
+		}
+
+		technologies.index[name] = len(technologies.fingerprints)
+		technologies.fingerprints = append(technologies.fingerprints, compiled)
+	}
+
+	return technologies
+}
+
+// loadTechnologies loads and compiles the fingerprints database.
+// If filename is empty the default database is used.
+func loadTechnologies(filename string) *Technologies {
+	if filename == "" {
+		return NewTechnologies(scanner.GetFingerprints())
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	fingerprints, err := scanner.ParseFingerprints(data)
+	if err != nil {
+		fmt.Println("The fingerprints file " + filename + " is not valid: " + err.Error())
+		os.Exit(1)
+	}
+
+	return NewTechnologies(fingerprints)
+}
+
+// compilePatterns compiles a list of Wappalyzer patterns.
+func compilePatterns(patterns scanner.Patterns) []techPattern {
+	result := []techPattern{}
+
+	for _, pattern := range patterns {
+		parts := strings.Split(pattern, `\;`)
+
+		regex, err := regexp.Compile("(?i)" + parts[0])
+		if err != nil {
+			continue
+		}
+
+		compiled := techPattern{regex: regex}
+
+		for _, tag := range parts[1:] {
+			if strings.HasPrefix(tag, "version:") {
+				compiled.version = strings.TrimPrefix(tag, "version:")
+			}
+		}
+
+		result = append(result, compiled)
+	}
+
+	return result
+}
+
+// compilePatternsMap compiles a map of Wappalyzer patterns.
+// Keys are lowercased.
+func compilePatternsMap(patterns map[string]scanner.Patterns) map[string][]techPattern {
+	result := make(map[string][]techPattern)
+
+	for key, value := range patterns {
+		result[strings.ToLower(key)] = compilePatterns(value)
+	}
+
+	return result
+}
+
+// match checks if the pattern matches the value and
+// returns the version detected (if any).
+func (p techPattern) match(value string) (bool, string) {
+	groups := p.regex.FindStringSubmatch(value)
+	if groups == nil {
+		return false, ""
+	}
+
+	return true, techVersion(p.version, groups)
+}
+
+// techVersion builds the version from a Wappalyzer
+// version template (e.g. `\1` or `\1?\1:fallback`).
+func techVersion(template string, groups []string) string {
+	group := func(index string) string {
+		i, err := strconv.Atoi(index)
+		if err != nil || i >= len(groups) {
+			return ""
+		}
+
+		return groups[i]
+	}
+
+	if ternary := versionTernaryRegex.FindStringSubmatch(template); ternary != nil {
+		if group(ternary[1]) != "" {
+			template = ternary[2]
+		} else {
+			template = ternary[3]
+		}
+	}
+
+	version := versionGroupRegex.ReplaceAllStringFunc(template, func(s string) string {
+		return group(s[1:])
+	})
+
+	return strings.TrimSpace(version)
+}
+
+// huntTechnologies hunts for the technologies used by a host.
+func huntTechnologies(technologies *Technologies, target string,
+	headers *http.Header, body string) []scanner.TechnologyMatched {
+	technologiesSlice := TechnologiesMatch(technologies, target, headers, body)
+	return technologiesSlice
+}
+
+// TechnologiesMatch detects the technologies used by a host matching
+// the fingerprints against the url, the headers, the cookies, the meta tags,
+// the script URLs and the body of a response.
+func TechnologiesMatch(technologies *Technologies, target string,
+	headers *http.Header, body string) []scanner.TechnologyMatched {
+	host := urlUtils.GetHost(target)
+	contentType := strings.ToLower(headers.Get("Content-Type"))
+	isHTML := strings.Contains(contentType, "html")
+	isScript := strings.Contains(contentType, "javascript") ||
+		strings.HasSuffix(strings.Split(target, "?")[0], ".js")
+
+	response := http.Response{Header: http.Header{"Set-Cookie": headers.Values("Set-Cookie")}}
+	cookies := make(map[string]string)
+
+	for _, cookie := range response.Cookies() {
+		cookies[cookie.Name] = cookie.Value
+	}
+
+	meta := make(map[string][]string)
+	scriptSources := []string{}
+
+	if isHTML {
+		meta = metaTags(body)
+
+		for _, src := range scriptSrcRegex.FindAllStringSubmatch(body, -1) {
+			scriptSources = append(scriptSources, src[1])
+		}
+	}
+
+	detected := make(map[string]int)
+	result := []scanner.TechnologyMatched{}
+
+	for _, fingerprint := range technologies.fingerprints {
+		matched, version, evidence := fingerprint.detect(target, headers, cookies, meta, scriptSources,
+			body, isHTML, isScript)
+		if !matched {
+			continue
+		}
+
+		technology := fingerprint.technology
+		technology.Version = version
+		detected[technology.Name] = len(result)
+		result = append(result, scanner.TechnologyMatched{Technology: technology, Host: host,
+			URL: target, Match: evidence})
+	}
+
+	// Add the implied technologies (e.g. WordPress implies PHP).
+	for i := 0; i < len(result); i++ {
+		index, ok := technologies.index[result[i].Technology.Name]
+		if !ok {
+			continue
+		}
+
+		for _, implied := range technologies.fingerprints[index].implies {
+			if _, ok := detected[implied]; ok {
+				continue
+			}
+
+			technology := scanner.Technology{Name: implied}
+			if impliedIndex, ok := technologies.index[implied]; ok {
+				technology = technologies.fingerprints[impliedIndex].technology
+			}
+
+			detected[implied] = len(result)
+			result = append(result, scanner.TechnologyMatched{Technology: technology, Host: host,
+				URL: target, Match: "implied by " + result[i].Technology.Name})
+		}
+	}
+
+	return result
+}
+
+// detect checks if a fingerprint matches a response.
+// It returns the version and the evidence of the first match,
+// preferring the matches with a version.
+func (f techFingerprint) detect(target string, headers *http.Header, cookies map[string]string,
+	meta map[string][]string, scriptSources []string, body string,
+	isHTML, isScript bool) (bool, string, string) {
+	var (
+		matched  bool
+		version  string
+		evidence string
+	)
+
+	check := func(patterns []techPattern, value, description string) {
+		for _, pattern := range patterns {
+			if version != "" {
+				return
+			}
+
+			if ok, v := pattern.match(value); ok {
+				if !matched || v != "" {
+					matched, version, evidence = true, v, description
+				}
+			}
+		}
+	}
+
+	check(f.url, target, "url: "+target)
+
+	for name, patterns := range f.headers {
+		for _, value := range headers.Values(name) {
+			check(patterns, value, http.CanonicalHeaderKey(name)+": "+value)
+		}
+	}
+
+	for name, patterns := range f.cookies {
+		for cookie, value := range cookies {
+			// Cookie names ending with a separator are prefixes (e.g. incap_ses_).
+			if strings.EqualFold(cookie, name) ||
+				(strings.HasSuffix(name, "_") || strings.HasSuffix(name, "-")) &&
+					strings.HasPrefix(strings.ToLower(cookie), name) {
+				check(patterns, value, "cookie: "+cookie)
+			}
+		}
+	}
+
+	for name, patterns := range f.meta {
+		for _, content := range meta[name] {
+			check(patterns, content, "meta "+name+": "+content)
+		}
+	}
+
+	for _, src := range scriptSources {
+		check(f.scriptSrc, src, "script: "+src)
+	}
+
+	if isHTML {
+		for _, pattern := range f.html {
+			if match := pattern.regex.FindString(body); match != "" {
+				check([]techPattern{pattern}, match, "html: "+techEvidence(match))
+			}
+		}
+	}
+
+	if isScript {
+		for _, pattern := range f.scripts {
+			if match := pattern.regex.FindString(body); match != "" {
+				check([]techPattern{pattern}, match, "script content: "+techEvidence(match))
+			}
+		}
+	}
+
+	return matched, version, evidence
+}
+
+// metaTags returns the meta tags (name, property or http-equiv
+// lowercased -> content) of an HTML page.
+func metaTags(body string) map[string][]string {
+	result := make(map[string][]string)
+
+	for _, tag := range metaTagRegex.FindAllString(body, -1) {
+		var name, content string
+
+		for _, attribute := range tagAttributeRegex.FindAllStringSubmatch(tag, -1) {
+			value := attribute[2] + attribute[3] + attribute[4]
+
+			switch strings.ToLower(attribute[1]) {
+			case "name", "property", "http-equiv":
+				name = strings.ToLower(value)
+			case "content":
+				content = value
+			}
+		}
+
+		if name != "" {
+			result[name] = append(result[name], content)
+		}
+	}
+
+	return result
+}
+
+// techEvidence truncates a body snippet used as evidence.
+func techEvidence(match string) string {
+	match = strings.Join(strings.Fields(match), " ")
+	if len(match) > techEvidenceLength {
+		return match[:techEvidenceLength] + "..."
+	}
+
+	return match
+}
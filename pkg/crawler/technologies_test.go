@@ -0,0 +1,105 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/output"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestTechnologiesMatch(t *testing.T) {
+	technologies := crawler.NewTechnologies(scanner.GetFingerprints())
+	tests := []struct {
+		name    string
+		target  string
+		headers http.Header
+		body    string
+		want    []string
+	}{
+		{
+			name:    "no technologies",
+			target:  "https://example.com/",
+			headers: http.Header{"Content-Type": {"text/plain"}},
+			body:    "hello",
+			want:    []string{},
+		},
+		{
+			name:   "server header with version",
+			target: "https://example.com/",
+			headers: http.Header{
+				"Content-Type": {"text/html"},
+				"Server":       {"nginx/1.18.0"},
+			},
+			body: "<html></html>",
+			want: []string{"Nginx 1.18.0 (Web servers, Reverse proxies)"},
+		},
+		{
+			name:   "cookie and implied technology",
+			target: "https://example.com/",
+			headers: http.Header{
+				"Content-Type": {"text/html"},
+				"Set-Cookie":   {"laravel_session=abc; Path=/"},
+			},
+			body: "<html></html>",
+			want: []string{"Laravel (Web frameworks)", "PHP (Programming languages)"},
+		},
+		{
+			name:    "meta generator and script src",
+			target:  "https://example.com/blog/",
+			headers: http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+			body: `<html><head><meta name="generator" content="WordPress 6.1.1" />` +
+				`<script src="/static/jquery-3.5.1.min.js"></script></head></html>`,
+			want: []string{"WordPress 6.1.1 (CMS)", "jQuery 3.5.1 (JavaScript libraries)",
+				"PHP (Programming languages)", "MySQL (Programming languages)"},
+		},
+		{
+			name:    "html patterns ignored outside html",
+			target:  "https://example.com/data.json",
+			headers: http.Header{"Content-Type": {"application/json"}},
+			body:    `{"html": "<div ng-app=\"app\">"}`,
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, elem := range crawler.TechnologiesMatch(technologies, tt.target, &tt.headers, tt.body) {
+				got = append(got, output.FormatTechnology(elem))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TechnologiesMatch\n%v", got)
+				t.Errorf("want\n%v", tt.want)
+			}
+		})
+	}
+}
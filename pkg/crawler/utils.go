@@ -28,7 +28,10 @@ package crawler
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
+	"sync"
 
 	urlUtils "github.com/edoardottt/cariddi/internal/url"
 )
@@ -69,3 +72,54 @@ func intensiveOk(target string, urlInput string, debug bool) bool {
 
 	return root == target
 }
+
+// seenSet is a set of keys safe for concurrent use.
+// It's used to report a finding only once while crawling.
+type seenSet struct {
+	mutex sync.Mutex
+	keys  map[string]bool
+}
+
+// newSeenSet returns an empty seenSet.
+func newSeenSet() *seenSet {
+	return &seenSet{keys: make(map[string]bool)}
+}
+
+// add adds a key to the set, it returns false if the key
+// was already present.
+func (s *seenSet) add(key string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.keys[key] {
+		return false
+	}
+
+	s.keys[key] = true
+
+	return true
+}
+
+// parameterNames returns the sorted names of the
+// query parameters of a URL.
+func parameterNames(u *url.URL) []string {
+	parameters := []string{}
+	for parameter := range u.Query() {
+		parameters = append(parameters, parameter)
+	}
+
+	sort.Strings(parameters)
+
+	return parameters
+}
+
+// endpointKey returns the key identifying an endpoint
+// (scheme, host, path and parameter names).
+func endpointKey(target string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+
+	return u.Scheme + u.Host + u.Path + strings.Join(parameterNames(u), "&")
+}
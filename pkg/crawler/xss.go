@@ -0,0 +1,304 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+const (
+	// reflectionSnippetLength is the number of characters kept
+	// around a reflected canary.
+	reflectionSnippetLength = 30
+)
+
+// nolint: gochecknoglobals
+var attributeRegex = regexp.MustCompile(`([\w:-]+)\s*=\s*(?:"([^"]*)|'([^']*)|([^\s"'>]*))$`)
+
+// urlAttributes returns the attributes holding a URL.
+func urlAttributes() []string {
+	return []string{"href", "src", "action", "formaction", "data", "poster", "background", "cite", "xlink:href"}
+}
+
+// reflectionCandidate checks if the parameters of an endpoint
+// can be tested for reflections.
+func reflectionCandidate(target string) bool {
+	u, err := url.Parse(target)
+	return err == nil && u.RawQuery != "" && len(u.Query()) != 0
+}
+
+// huntReflections injects a harmless canary in every parameter of an
+// endpoint and returns the reflection points found. If the canary is
+// reflected, a second request checks the special characters filtering.
+func huntReflections(client *activeClient, target string) []scanner.ReflectionMatched {
+	results := []scanner.ReflectionMatched{}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return results
+	}
+
+	for _, parameter := range parameterNames(u) {
+		canary := newCanary()
+
+		resp, body, err := client.Do(http.MethodGet, injectParameter(u, parameter, canary), nil)
+		if err != nil || !isHTML(resp) || !strings.Contains(string(body), canary) {
+			continue
+		}
+
+		probe := canary + strings.Join(scanner.GetReflectionCharacters(), "") + canary
+
+		_, probeBody, err := client.Do(http.MethodGet, injectParameter(u, parameter, probe), nil)
+		if err != nil {
+			probeBody = []byte{}
+		}
+
+		results = append(results, ReflectionsMatch(target, parameter, string(body), string(probeBody), canary)...)
+	}
+
+	return results
+}
+
+// ReflectionsMatch returns the reflection points of a canary injected in
+// a parameter. body is the response to the canary, probe is the response
+// to the canary followed by the special characters (and the canary again).
+// The reflections are grouped by context.
+func ReflectionsMatch(target, parameter, body, probe, canary string) []scanner.ReflectionMatched {
+	results := []scanner.ReflectionMatched{}
+	indexes := map[string]int{}
+	segments := probeSegments(probe, canary)
+	occurrence := 0
+
+	for start := 0; ; occurrence++ {
+		i := strings.Index(body[start:], canary)
+		if i < 0 {
+			break
+		}
+
+		i += start
+		start = i + len(canary)
+		context, quote := reflectionContext(body, i)
+
+		// Characters reflected in this occurrence (if the occurrences of the probe
+		// match the ones of the canary), otherwise in any occurrence.
+		var characters []string
+		if len(segments) == strings.Count(body, canary) {
+			characters = reflectedCharacters(segments[occurrence])
+		} else {
+			characters = reflectedCharacters(strings.Join(segments, ""))
+		}
+
+		reflection := scanner.Reflection{
+			Parameter:  parameter,
+			Context:    context,
+			Characters: characters,
+			Severity:   reflectionSeverity(context, quote, characters),
+		}
+
+		index, ok := indexes[context]
+		if !ok {
+			indexes[context] = len(results)
+			results = append(results, scanner.ReflectionMatched{Reflection: reflection, URL: target,
+				Match: snippet(body, i, len(canary))})
+
+			continue
+		}
+
+		// Keep the most severe occurrence of a context.
+		if scanner.SeverityRank(reflection.Severity) > scanner.SeverityRank(results[index].Reflection.Severity) {
+			results[index].Reflection = reflection
+			results[index].Match = snippet(body, i, len(canary))
+		}
+	}
+
+	return results
+}
+
+// reflectionContext returns the context of a reflection at index i of the body
+// and the quote delimiting it (if it's in an attribute).
+func reflectionContext(body string, i int) (string, string) {
+	before := body[:i]
+	lower := strings.ToLower(before)
+
+	// Inside a script block (not in the attributes of the script tag).
+	if open := strings.LastIndex(lower, "<script"); open >= 0 && open > strings.LastIndex(lower, "</script") {
+		if strings.Contains(before[open:], ">") {
+			return scanner.ReflectionScript, ""
+		}
+	}
+
+	// Inside a tag.
+	if strings.LastIndex(before, "<") > strings.LastIndex(before, ">") {
+		tag := before[strings.LastIndex(before, "<"):]
+
+		match := attributeRegex.FindStringSubmatchIndex(tag)
+		if match == nil {
+			return scanner.ReflectionAttribute, ""
+		}
+
+		name := strings.ToLower(tag[match[2]:match[3]])
+		quote, prefix := "", ""
+
+		switch {
+		case match[4] >= 0:
+			quote, prefix = `"`, tag[match[4]:match[5]]
+		case match[6] >= 0:
+			quote, prefix = `'`, tag[match[6]:match[7]]
+		default:
+			prefix = tag[match[8]:match[9]]
+		}
+
+		for _, attribute := range urlAttributes() {
+			if name == attribute && prefix == "" {
+				return scanner.ReflectionURL, quote
+			}
+		}
+
+		return scanner.ReflectionAttribute, quote
+	}
+
+	return scanner.ReflectionHTML, ""
+}
+
+// reflectionSeverity returns the severity of a reflection point
+// given its context and the special characters reflected unfiltered.
+func reflectionSeverity(context, quote string, characters []string) string {
+	reflected := map[string]bool{}
+	for _, character := range characters {
+		reflected[character] = true
+	}
+
+	tags := reflected["<"] && reflected[">"]
+
+	switch context {
+	case scanner.ReflectionHTML:
+		if tags {
+			return scanner.SeverityHigh
+		}
+	case scanner.ReflectionAttribute:
+		if (quote != "" && reflected[quote]) || (quote == "" && tags) {
+			return scanner.SeverityHigh
+		}
+	case scanner.ReflectionURL:
+		// A URL context allows javascript: URIs even without special characters.
+		if quote != "" && reflected[quote] {
+			return scanner.SeverityHigh
+		}
+
+		return scanner.SeverityMedium
+	case scanner.ReflectionScript:
+		if tags || reflected[`"`] || reflected[`'`] || reflected["`"] {
+			return scanner.SeverityHigh
+		}
+
+		return scanner.SeverityMedium
+	}
+
+	return scanner.SeverityLow
+}
+
+// probeSegments returns the content between each pair
+// of canaries in the response to the probe.
+func probeSegments(probe, canary string) []string {
+	segments := []string{}
+
+	for {
+		i := strings.Index(probe, canary)
+		if i < 0 {
+			break
+		}
+
+		probe = probe[i+len(canary):]
+
+		j := strings.Index(probe, canary)
+		if j < 0 {
+			break
+		}
+
+		segments = append(segments, probe[:j])
+		probe = probe[j+len(canary):]
+	}
+
+	return segments
+}
+
+// reflectedCharacters returns the special characters in a segment.
+func reflectedCharacters(segment string) []string {
+	characters := []string{}
+
+	for _, character := range scanner.GetReflectionCharacters() {
+		if strings.Contains(segment, character) {
+			characters = append(characters, character)
+		}
+	}
+
+	return characters
+}
+
+// snippet returns a reflection along with its surroundings.
+func snippet(body string, i, length int) string {
+	start := i - reflectionSnippetLength
+	if start < 0 {
+		start = 0
+	}
+
+	end := i + length + reflectionSnippetLength
+	if end > len(body) {
+		end = len(body)
+	}
+
+	return body[start:end]
+}
+
+// newCanary returns a unique and harmless canary.
+func newCanary() string {
+	return fmt.Sprintf("%s%08x", scanner.ReflectionCanaryPrefix, rand.Uint32()) // nolint: gosec
+}
+
+// injectParameter returns the URL with the value of a parameter replaced.
+func injectParameter(u *url.URL, parameter, value string) string {
+	injected := *u
+	query := u.Query()
+	query.Set(parameter, value)
+	injected.RawQuery = query.Encode()
+
+	return injected.String()
+}
+
+// isHTML checks if a response is an HTML page
+// (responses without a content type included).
+func isHTML(resp *http.Response) bool {
+	contentType := resp.Header.Get("Content-Type")
+	return contentType == "" || strings.Contains(strings.ToLower(contentType), "html")
+}
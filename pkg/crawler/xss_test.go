@@ -0,0 +1,150 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package crawler_test
+
+import (
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/crawler"
+	"github.com/edoardottt/cariddi/pkg/output"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestReflectionsMatch(t *testing.T) {
+	const canary = "cariddi0badc0de"
+
+	probe := canary + `<>"'` + "`" + canary
+
+	tests := []struct {
+		name  string
+		body  string
+		probe string
+		want  []string
+	}{
+		{
+			name:  "not reflected",
+			body:  `<html><p>nothing here</p></html>`,
+			probe: `<html><p>nothing here</p></html>`,
+			want:  []string{},
+		},
+		{
+			name:  "html context unfiltered",
+			body:  `<p>Results for ` + canary + `</p>`,
+			probe: `<p>Results for ` + probe + `</p>`,
+			want:  []string{"q - html context [unfiltered: < > \" ' `] (high)"},
+		},
+		{
+			name:  "html context encoded",
+			body:  `<p>Results for ` + canary + `</p>`,
+			probe: `<p>Results for ` + html.EscapeString(probe) + `</p>`,
+			want:  []string{"q - html context [unfiltered: `] (low)"},
+		},
+		{
+			name:  "quoted attribute",
+			body:  `<input type="text" value="` + canary + `">`,
+			probe: `<input type="text" value="` + canary + `&lt;&gt;"'` + "`" + canary + `">`,
+			want:  []string{"q - attribute context [unfiltered: \" ' `] (high)"},
+		},
+		{
+			name:  "url attribute",
+			body:  `<a href='` + canary + `'>next</a>`,
+			probe: `<a href='` + canary + `&lt;&gt;&quot;&#39;` + "`" + canary + `'>next</a>`,
+			want:  []string{"q - url context [unfiltered: `] (medium)"},
+		},
+		{
+			name:  "script and html contexts",
+			body:  `<script>var q = "` + canary + `";</script><h1>` + canary + `</h1>`,
+			probe: `<script>var q = "` + canary + `\"'` + "`" + canary + `";</script><h1>` + html.EscapeString(probe) + `</h1>`,
+			want: []string{
+				"q - script context [unfiltered: \" ' `] (high)",
+				"q - html context [unfiltered: `] (low)",
+			},
+		},
+		{
+			name:  "script source is an url",
+			body:  `<script src="` + canary + `"></script>`,
+			probe: ``,
+			want:  []string{"q - url context (medium)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, elem := range crawler.ReflectionsMatch("https://example.com/?q=1", "q", tt.body, tt.probe, canary) {
+				got = append(got, output.FormatReflection(elem))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReflectionsMatch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReflections(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><a href="/search?q=test&lang=en">search</a></html>`))
+	})
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><h1>Results for ` + r.URL.Query().Get("q") + `</h1>` +
+			`<input name="lang" value="` + html.EscapeString(r.URL.Query().Get("lang")) + `"></html>`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	results := crawler.New(&crawler.Scan{
+		Target:      server.URL,
+		Concurrency: 1,
+		Timeout:     5,
+		JSON:        true,
+		XSSFlag:     true,
+	})
+
+	got := []string{}
+	for _, elem := range scanner.RemoveDuplicateReflections(results.Reflections) {
+		got = append(got, output.FormatReflection(elem))
+	}
+
+	want := []string{
+		"q - html context [unfiltered: < > \" ' `] (high)",
+		"lang - attribute context [unfiltered: `] (low)",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reflections\n%v", got)
+		t.Errorf("want\n%v", want)
+	}
+}
@@ -30,8 +30,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	decodeUtils "github.com/edoardottt/cariddi/internal/decode"
 	fileUtils "github.com/edoardottt/cariddi/internal/file"
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
 )
 
 // CheckOutputFile checks if the string provided as input
@@ -63,6 +67,34 @@ func CheckFlags(flags Input) {
 		}
 	}
 
+	if flags.SARIFout != "" {
+		if !CheckOutputFile(flags.SARIFout) {
+			fmt.Println("The output file must avoid weird symbols. Try to use - , _ , . instead.")
+			os.Exit(1)
+		}
+	}
+
+	if flags.JSONout != "" {
+		if !CheckOutputFile(flags.JSONout) {
+			fmt.Println("The output file must avoid weird symbols. Try to use - , _ , . instead.")
+			os.Exit(1)
+		}
+	}
+
+	if flags.CSVout != "" {
+		if !CheckOutputFile(flags.CSVout) {
+			fmt.Println("The output file must avoid weird symbols. Try to use - , _ , . instead.")
+			os.Exit(1)
+		}
+	}
+
+	if flags.MDout != "" {
+		if !CheckOutputFile(flags.MDout) {
+			fmt.Println("The output file must avoid weird symbols. Try to use - , _ , . instead.")
+			os.Exit(1)
+		}
+	}
+
 	if flags.Extensions != 0 {
 		if !(1 <= flags.Extensions && flags.Extensions <= 7) {
 			fmt.Println("The extension value must go from 1 (juicy) to 7 (not juicy).")
@@ -78,6 +110,14 @@ func CheckFlags(flags Input) {
 		}
 	}
 
+	if flags.PathsFile != "" {
+		if !flags.Endpoints {
+			fmt.Println("You can't define a paths file and not the endpoint search.")
+			fmt.Println("If you want to scan for custom paths enter both -e and -pf {filename}.")
+			os.Exit(1)
+		}
+	}
+
 	if flags.SecretsFile != "" {
 		if !flags.Secrets {
 			fmt.Println("You can't define a secrets file and not the secrets search.")
@@ -86,11 +126,93 @@ func CheckFlags(flags Input) {
 		}
 	}
 
-	if flags.Plain && flags.TXTout == "" && flags.HTMLout == "" {
-		if flags.Secrets || flags.Endpoints || flags.Extensions != 0 {
+	if flags.TechFile != "" {
+		if !flags.Tech {
+			fmt.Println("You can't define a fingerprints file and not the technologies detection.")
+			fmt.Println("If you want to use custom fingerprints enter both -tech and -tf {filename}.")
+			os.Exit(1)
+		}
+	}
+
+	if flags.JSLibsFile != "" {
+		if !flags.JSLibs {
+			fmt.Println("You can't define a vulnerabilities file and not the JavaScript libraries search.")
+			fmt.Println("If you want to use a custom vulnerabilities database enter both -js and -jsf {filename}.")
+			os.Exit(1)
+		}
+	}
+
+	if flags.CloudCheck && !flags.Cloud {
+		fmt.Println("You can't check the buckets and not define the buckets search.")
+		fmt.Println("If you want to check if the buckets are publicly listable enter both -cloud and -cloud-check.")
+		os.Exit(1)
+	}
+
+	if flags.CloudEndpoint != "" {
+		if !flags.CloudCheck {
+			fmt.Println("You can't define a bucket endpoint and not the bucket check.")
+			fmt.Println("If you want to use a custom endpoint enter both -cloud-check and -cloud-endpoint {url}.")
+			os.Exit(1)
+		}
+
+		if !urlUtils.HasProtocol(flags.CloudEndpoint) {
+			fmt.Println("The bucket endpoint must be a URL (e.g. http://127.0.0.1:9000).")
+			os.Exit(1)
+		}
+	}
+
+	if flags.SpecFile != "" && !flags.API {
+		fmt.Println("You can't define an API specification and not the API specifications parsing.")
+		fmt.Println("If you want to use a local specification enter both -api and -spec {filename}.")
+		os.Exit(1)
+	}
+
+	if flags.RedirURL != "" {
+		if !flags.Redir {
+			fmt.Println("You can't define a redirect URL and not the open redirects verification.")
+			fmt.Println("If you want to use a custom controlled URL enter both -redir and -redir-url {url}.")
+			os.Exit(1)
+		}
+
+		if !urlUtils.HasProtocol(flags.RedirURL) || urlUtils.GetHost(flags.RedirURL) == "" {
+			fmt.Println("The redirect URL must be an absolute URL (e.g. https://example.com/).")
+			os.Exit(1)
+		}
+	}
+
+	if flags.MinSeverity != "" && scanner.SeverityRank(flags.MinSeverity) == 0 {
+		fmt.Println("The minimum severity must be one of: " + strings.Join(scanner.GetSeverities(), ", ") + ".")
+		os.Exit(1)
+	}
+
+	if flags.Redact && !flags.Secrets && flags.Decode == 0 && !flags.Cookies {
+		fmt.Println("You can't redact secrets and not define the secrets, tokens or cookies search.")
+		fmt.Println("If you want to mask the values found enter -redact with -s, -decode or -cookies.")
+		os.Exit(1)
+	}
+
+	if flags.RedactHash && !flags.Redact {
+		fmt.Println("You can't define the redaction fingerprint and not the redaction.")
+		fmt.Println("If you want to append a fingerprint to the secrets enter both -redact and -redact-hash.")
+		os.Exit(1)
+	}
+
+	if flags.Decode != 0 {
+		if !(1 <= flags.Decode && flags.Decode <= decodeUtils.MaxDepth) {
+			fmt.Printf("The decode depth must go from 1 to %d.\n", decodeUtils.MaxDepth)
+			os.Exit(1)
+		}
+	}
+
+	if flags.Plain && flags.TXTout == "" && flags.HTMLout == "" && flags.SARIFout == "" && flags.JSONout == "" &&
+		flags.CSVout == "" && flags.MDout == "" {
+		if flags.Secrets || flags.Endpoints || flags.Extensions != 0 || flags.Decode != 0 ||
+			flags.SecHeaders || flags.Cookies || flags.CORS || flags.Tech || flags.JSLibs ||
+			flags.Hosts || flags.Cloud || flags.API || flags.XSS ||
+			flags.Redir || flags.Backup {
 			fmt.Println("In the plain mode cariddi prints only links found on targets.")
 			fmt.Println("If you want to see the results of secrets, endpoints and extensions found")
-			fmt.Println("you should define a Txt or/and Html file output, or remove the plain mode.")
+			fmt.Println("you should define a file output (Txt, Html, SARIF, JSON, CSV or Markdown), or remove the plain mode.")
 			fmt.Println("Examples:")
 			fmt.Println("	- cat urls | cariddi -plain -s -ot {target-name}")
 			fmt.Println("	- cat urls | cariddi -s")
@@ -128,3 +250,36 @@ func CheckFlags(flags Input) {
 		os.Exit(1)
 	}
 }
+
+// CheckBaselineFlags checks if the options of the
+// baseline command are formatted in a correct way.
+func CheckBaselineFlags(flags BaselineInput) {
+	if flags.Status != scanner.BaselineAccepted && flags.Status != scanner.BaselineFalsePositive {
+		fmt.Println("The baseline status must be " + scanner.BaselineAccepted + " or " + scanner.BaselineFalsePositive + ".")
+		os.Exit(1)
+	}
+
+	if flags.Expires != "" {
+		if _, err := time.Parse(scanner.BaselineDateLayout, flags.Expires); err != nil {
+			fmt.Println("The expiry date must be in the YYYY-MM-DD format (e.g. 2025-12-31).")
+			os.Exit(1)
+		}
+	}
+}
+
+// CheckDiffFlags checks if the options of the
+// diff command are formatted in a correct way.
+func CheckDiffFlags(flags DiffInput) {
+	if len(flags.Files) != 2 {
+		fmt.Println("You must provide the JSON outputs of two scans.")
+		fmt.Println("Examples:")
+		fmt.Println("	- cariddi diff old.json new.json")
+		fmt.Println("	- cariddi diff -format md old.json new.json")
+		os.Exit(1)
+	}
+
+	if flags.Format != DiffJSON && flags.Format != DiffMarkdown && flags.Format != DiffHTML {
+		fmt.Println("The diff format must be one of: " + DiffJSON + ", " + DiffMarkdown + ", " + DiffHTML + ".")
+		os.Exit(1)
+	}
+}
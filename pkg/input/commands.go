@@ -0,0 +1,126 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package input
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+const (
+	// BaselineCommand turns the JSON output of a scan into a baseline.
+	BaselineCommand = "baseline"
+	// DiffCommand compares the JSON outputs of two scans.
+	DiffCommand = "diff"
+)
+
+// Diff formats.
+const (
+	DiffJSON     = "json"
+	DiffMarkdown = "md"
+	DiffHTML     = "html"
+)
+
+// BaselineInput struct.
+// It contains all the options of the baseline command.
+type BaselineInput struct {
+	// Status is the status of the findings (accepted or false-positive).
+	Status string
+	// Reason is why the findings are in the baseline.
+	Reason string
+	// Expires is the date (YYYY-MM-DD) after which the findings are reported again.
+	Expires string
+	// Output writes the baseline into a file instead of stdout.
+	Output string
+	// Files are the JSON outputs of the scans (stdin if empty).
+	Files []string
+}
+
+// ScanBaselineFlags defines all the options of the baseline
+// command and scan them, then it returns the options found.
+func ScanBaselineFlags(args []string) BaselineInput {
+	flags := flag.NewFlagSet(BaselineCommand, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage of cariddi baseline: cariddi baseline [options] [results.json ...]")
+		flags.PrintDefaults()
+	}
+
+	statusPtr := flags.String("status", scanner.BaselineAccepted, "Status of the findings (accepted, false-positive).")
+	reasonPtr := flags.String("reason", "", "Why the findings are accepted or false positives.")
+	expiresPtr := flags.String("expires", "", "Report again the findings after this date (YYYY-MM-DD).")
+	outputPtr := flags.String("o", "", "Write the baseline into a file instead of stdout.")
+
+	// ExitOnError: Parse never returns an error.
+	_ = flags.Parse(args)
+
+	result := BaselineInput{
+		*statusPtr,
+		*reasonPtr,
+		*expiresPtr,
+		*outputPtr,
+		flags.Args(),
+	}
+
+	return result
+}
+
+// DiffInput struct.
+// It contains all the options of the diff command.
+type DiffInput struct {
+	// Format is the format of the diff (json, md or html).
+	Format string
+	// Output writes the diff into a file instead of stdout.
+	Output string
+	// Files are the JSON outputs of the old and the new scan.
+	Files []string
+}
+
+// ScanDiffFlags defines all the options of the diff
+// command and scan them, then it returns the options found.
+func ScanDiffFlags(args []string) DiffInput {
+	flags := flag.NewFlagSet(DiffCommand, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage of cariddi diff: cariddi diff [options] old.json new.json")
+		flags.PrintDefaults()
+	}
+
+	formatPtr := flags.String("format", DiffJSON, "Format of the diff (json, md, html).")
+	outputPtr := flags.String("o", "", "Write the diff into a file instead of stdout.")
+
+	// ExitOnError: Parse never returns an error.
+	_ = flags.Parse(args)
+
+	result := DiffInput{
+		*formatPtr,
+		*outputPtr,
+		flags.Args(),
+	}
+
+	return result
+}
@@ -37,63 +37,119 @@ const (
 
 // Input struct.
 // It contains all the possible options.
+// The options that may contain credentials are left out of the JSON report.
 type Input struct {
 	// Version prints the version banner.
-	Version bool
+	Version bool `json:"-"`
 	// Delay between a page crawled and another.
-	Delay int
+	Delay int `json:"delay"`
 	// Concurrency level.
-	Concurrency int
+	Concurrency int `json:"concurrency"`
 	// Help prints the help banner.
-	Help bool
+	Help bool `json:"-"`
 	// Examples prints the examples banner.
-	Examples bool
+	Examples bool `json:"-"`
 	// Plain prints only the results.
-	Plain bool
+	Plain bool `json:"plain"`
 	// JSON prints the output as JSON in stdout.
-	JSON bool
+	JSON bool `json:"json"`
 	// HTMLout writes the output into an HTML file.
-	HTMLout string
+	HTMLout string `json:"html_output"`
 	// TXTout writes the output into an TXT file.
-	TXTout string
+	TXTout string `json:"txt_output"`
 	// Ignore ignores the URL containing at least one of the elements of this array.
-	Ignore string
+	Ignore string `json:"ignore"`
 	// IgnoreTXT ignores the URL containing at least one of the lines of this file.
-	IgnoreTXT string
+	IgnoreTXT string `json:"ignore_txt"`
 	// Cache uses the .cariddi_cache folder as cache.
-	Cache bool
+	Cache bool `json:"cache"`
 	// Timeout set timeout for the requests. (default 10)
-	Timeout int
+	Timeout int `json:"timeout"`
 	// Intensive crawls searching for resources matching 2nd level domain.
-	Intensive bool
+	Intensive bool `json:"intensive"`
 	// Rua uses a random browser user agent on every request.
-	Rua bool
+	Rua bool `json:"random_user_agent"`
 	// Proxy set a Proxy to be used (http and socks5 supported).
-	Proxy string
+	Proxy string `json:"-"`
 	// Secrets hunts for secrets.
-	Secrets bool
+	Secrets bool `json:"secrets"`
 	// SecretsFile uses an external file (txt, one per line) to use custom regexes for secrets hunting.
-	SecretsFile string
+	SecretsFile string `json:"secrets_file"`
 	// Endpoints hunts for juicy endpoints.
-	Endpoints bool
+	Endpoints bool `json:"endpoints"`
 	// EndpointsFile uses an external file (txt, one per line) to use custom parameters for endpoints hunting.
-	EndpointsFile string
+	EndpointsFile string `json:"endpoints_file"`
 	// Extensions hunts for juicy file extensions. Integer from 1(juicy) to 7(not juicy).
-	Extensions int
+	Extensions int `json:"extensions"`
 	// Headers uses custom headers for each request E.g. -headers "Cookie: auth=yes;;Client: type=2".
-	Headers string
+	Headers string `json:"-"`
 	// HeadersFile reads from an external file custom headers (same format of headers flag).
-	HeadersFile string
+	HeadersFile string `json:"headers_file"`
 	// Errors hunts for errors in websites.
-	Errors bool
+	Errors bool `json:"errors"`
 	// Info hunts for useful informations in websites.
-	Info bool
+	Info bool `json:"info"`
 	// Debug prints debug information while crawling.
-	Debug bool
+	Debug bool `json:"debug"`
 	// UserAgent uses a custom User Agent.
-	UserAgent string
+	UserAgent string `json:"user_agent"`
 	// StoreResp stores HTTP responses.
-	StoreResp bool
+	StoreResp bool `json:"store_responses"`
+	// Redact masks the secrets found in every output.
+	Redact bool `json:"redact"`
+	// RedactHash appends a hashed fingerprint to the redacted secrets.
+	RedactHash bool `json:"redact_hash"`
+	// Decode decodes the encoded content (base64, hex, URL-encoding, gzip, JWT)
+	// up to this depth and hunts in it.
+	Decode int `json:"decode_depth"`
+	// SecHeaders hunts for missing, weak and information leaking headers.
+	SecHeaders bool `json:"security_headers"`
+	// Cookies hunts for weak cookie attributes and values.
+	Cookies bool `json:"cookies"`
+	// CORS actively hunts for CORS misconfigurations.
+	CORS bool `json:"cors"`
+	// Tech detects the technologies used by the hosts.
+	Tech bool `json:"technologies"`
+	// TechFile reads the fingerprints from an external file (Wappalyzer format).
+	TechFile string `json:"technologies_file"`
+	// JSLibs hunts for vulnerable JavaScript libraries.
+	JSLibs bool `json:"js_libraries"`
+	// JSLibsFile reads the vulnerabilities from an external file (Retire.js format).
+	JSLibsFile string `json:"js_libraries_file"`
+	// Hosts harvests the hostnames found while crawling.
+	Hosts bool `json:"hosts"`
+	// Cloud hunts for cloud storage buckets.
+	Cloud bool `json:"cloud"`
+	// CloudCheck checks if the buckets found are publicly listable.
+	CloudCheck bool `json:"cloud_check"`
+	// CloudEndpoint is the (path-style) endpoint used for the bucket checks.
+	CloudEndpoint string `json:"cloud_endpoint"`
+	// API parses the API specifications (OpenAPI/Swagger, GraphQL) found.
+	API bool `json:"api"`
+	// SpecFile is a local OpenAPI/Swagger specification used as crawl seeds.
+	SpecFile string `json:"spec_file"`
+	// PathsFile uses an external file (txt, one per line) to use custom regexes for juicy paths hunting.
+	PathsFile string `json:"paths_file"`
+	// XSS injects a canary in the parameters and hunts for reflections.
+	XSS bool `json:"xss"`
+	// Redir verifies the open redirects injecting a controlled URL in the redirect-like parameters.
+	Redir bool `json:"redirects"`
+	// RedirURL is the controlled external URL injected.
+	RedirURL string `json:"redirect_url"`
+	// Backup probes the version control files and the backup copies of the files crawled.
+	Backup bool `json:"backup"`
+	// MinSeverity reports only the findings with at least this severity.
+	MinSeverity string `json:"min_severity"`
+	// Baseline suppresses the findings listed in this file.
+	Baseline string `json:"baseline"`
+	// SARIFout writes the output into a SARIF file.
+	SARIFout string `json:"sarif_output"`
+	// JSONout writes the final results into a JSON report file.
+	JSONout string `json:"json_output"`
+	// CSVout writes the findings into a CSV file for each type.
+	CSVout string `json:"csv_output"`
+	// MDout writes the findings into a Markdown file.
+	MDout string `json:"markdown_output"`
 }
 
 // ScanFlag defines all the options taken
@@ -102,7 +158,7 @@ type Input struct {
 func ScanFlag() Input {
 	versionPtr := flag.Bool("version", false, "Print the version.")
 	delayPtr := flag.Int("d", 0, "Delay between a page crawled and another.")
-	concurrencyPtr := flag.Int("c", DefaultConcurrency, "Concurrency level.")
+	concurrencyPtr := flag.Int("c", DefaultConcurrency, "Concurrency level, active checks included.")
 	helpPtr := flag.Bool("h", false, "Print the help.")
 	examplesPtr := flag.Bool("examples", false, "Print the examples.")
 	plainPtr := flag.Bool("plain", false, "Print only results.")
@@ -134,7 +190,7 @@ func ScanFlag() Input {
 
 	errorsPtr := flag.Bool("err", false, "Hunt for errors in websites.")
 
-	infoPtr := flag.Bool("info", false, "Hunt for useful informations in websites.")
+	infoPtr := flag.Bool("info", false, "Hunt for useful informations in websites and in document metadata.")
 
 	debugPtr := flag.Bool("debug", false, "Print debug information while crawling.")
 
@@ -142,6 +198,63 @@ func ScanFlag() Input {
 
 	storeRespPtr := flag.Bool("sr", false, "Store HTTP responses.")
 
+	redactPtr := flag.Bool("redact", false,
+		"Mask the secrets, tokens and cookie values found in every output (stdout, files and stored responses).")
+	redactHashPtr := flag.Bool("redact-hash", false, "Append a hashed fingerprint to the redacted secrets.")
+
+	decodePtr := flag.Int("decode", 0, "Decode the encoded content (base64, hex, URL-encoding, gzip, JWT)"+
+		" up to this depth and hunt in it.")
+
+	secHeadersPtr := flag.Bool("hdr", false, "Hunt for missing, weak and information leaking headers.")
+
+	cookiesPtr := flag.Bool("cookies", false, "Hunt for weak cookie attributes and values.")
+
+	corsPtr := flag.Bool("cors", false, "Actively hunt for CORS misconfigurations (crafted Origin headers).")
+
+	techPtr := flag.Bool("tech", false, "Detect the technologies used by the hosts (CMS, frameworks, servers...).")
+	techFilePtr := flag.String("tf", "", "Use an external file (Wappalyzer format)"+
+		" to use custom fingerprints for technologies detection.")
+
+	jsLibsPtr := flag.Bool("js", false, "Hunt for vulnerable JavaScript libraries.")
+	jsLibsFilePtr := flag.String("jsf", "", "Use an external file (Retire.js format)"+
+		" to use a custom vulnerabilities database for JavaScript libraries hunting.")
+
+	hostsPtr := flag.Bool("hosts", false, "Harvest the hostnames found (links, bodies, CSP, CORS and certificates).")
+
+	cloudPtr := flag.Bool("cloud", false, "Hunt for cloud storage buckets (S3, GCS, Azure Blob, DigitalOcean Spaces).")
+	cloudCheckPtr := flag.Bool("cloud-check", false, "Check if the buckets found are publicly listable.")
+	cloudEndpointPtr := flag.String("cloud-endpoint", "", "Use a custom (path-style) endpoint for the bucket checks"+
+		" E.g. http://127.0.0.1:9000.")
+
+	apiPtr := flag.Bool("api", false, "Parse the API specifications found (OpenAPI/Swagger, GraphQL introspection).")
+	specFilePtr := flag.String("spec", "", "Use a local OpenAPI/Swagger specification (JSON or YAML) as crawl seeds.")
+
+	pathsFilePtr := flag.String("pf", "", "Use an external file (txt, one per line)"+
+		" to use custom regexes for juicy paths hunting.")
+
+	xssPtr := flag.Bool("xss", false, "Actively hunt for XSS reflection points (harmless canary in the parameters).")
+
+	redirPtr := flag.Bool("redir", false, "Verify the open redirects (controlled URL in the redirect-like parameters).")
+	redirURLPtr := flag.String("redir-url", "", "Use a custom controlled URL for the open redirects verification"+
+		" E.g. https://example.com/.")
+
+	backupPtr := flag.Bool("backup", false, "Probe the version control files and the backup copies"+
+		" (~, .bak, .old, .swp...) of the files crawled.")
+
+	minSeverityPtr := flag.String("min-severity", "", "Report only the findings with at least this severity"+
+		" (info, low, medium, high, critical).")
+
+	baselinePtr := flag.String("baseline", "", "Suppress the accepted and false positive findings"+
+		" listed in this file (see cariddi baseline).")
+
+	outputSARIFPtr := flag.String("osarif", "", "Write the output into a SARIF (2.1.0) file.")
+
+	outputJSONPtr := flag.String("oj", "", "Write the final results into a JSON report file.")
+
+	outputCSVPtr := flag.String("ocsv", "", "Write the findings into a CSV file for each type.")
+
+	outputMDPtr := flag.String("omd", "", "Write the findings into a Markdown file.")
+
 	flag.Parse()
 
 	result := Input{
@@ -173,6 +286,33 @@ func ScanFlag() Input {
 		*debugPtr,
 		*userAgentPtr,
 		*storeRespPtr,
+		*redactPtr,
+		*redactHashPtr,
+		*decodePtr,
+		*secHeadersPtr,
+		*cookiesPtr,
+		*corsPtr,
+		*techPtr,
+		*techFilePtr,
+		*jsLibsPtr,
+		*jsLibsFilePtr,
+		*hostsPtr,
+		*cloudPtr,
+		*cloudCheckPtr,
+		*cloudEndpointPtr,
+		*apiPtr,
+		*specFilePtr,
+		*pathsFilePtr,
+		*xssPtr,
+		*redirPtr,
+		*redirURLPtr,
+		*backupPtr,
+		*minSeverityPtr,
+		*baselinePtr,
+		*outputSARIFPtr,
+		*outputJSONPtr,
+		*outputCSVPtr,
+		*outputMDPtr,
 	}
 
 	return result
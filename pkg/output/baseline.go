@@ -0,0 +1,216 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	fileUtils "github.com/edoardottt/cariddi/internal/file"
+	"github.com/edoardottt/cariddi/pkg/input"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// baselineBuilder collects the entries of a baseline,
+// each finding is added only once.
+type baselineBuilder struct {
+	status  string
+	reason  string
+	expires string
+	entries []scanner.BaselineEntry
+	seen    map[string]bool
+}
+
+// add adds a finding to the baseline.
+func (b *baselineBuilder) add(fingerprint, finding, url string) {
+	if fingerprint == "" || b.seen[fingerprint] {
+		return
+	}
+
+	b.seen[fingerprint] = true
+	b.entries = append(b.entries, scanner.BaselineEntry{
+		Fingerprint: fingerprint,
+		Finding:     finding,
+		URL:         url,
+		Status:      b.status,
+		Reason:      b.reason,
+		Expires:     b.expires,
+	})
+}
+
+// findingURL returns the location of the finding if it's
+// defined (inside an archive), the url of the response otherwise.
+func findingURL(location, url string) string {
+	if location != "" {
+		return location
+	}
+
+	return url
+}
+
+// addMatches adds all the findings of a JSON line to the baseline.
+func (b *baselineBuilder) addMatches(data *JSONData) {
+	matches, url := data.Matches, data.URL
+	if matches == nil {
+		return
+	}
+
+	if matches.FileType != nil {
+		b.add(matches.FileType.Fingerprint, "filetype: "+matches.FileType.Extension, url)
+	}
+
+	for _, file := range matches.Files {
+		b.add(file.Fingerprint, "filetype: "+file.Extension, findingURL(file.Location, url))
+	}
+
+	for _, parameter := range matches.Parameters {
+		b.add(parameter.Fingerprint, "parameter: "+parameter.Parameter.Parameter, url)
+	}
+
+	for _, path := range matches.Paths {
+		b.add(path.Fingerprint, "path: "+path.Name, url)
+	}
+
+	for _, e := range matches.Errors {
+		b.add(e.Fingerprint, "error: "+e.Name, findingURL(e.Location, url))
+	}
+
+	for _, info := range matches.Infos {
+		b.add(info.Fingerprint, "info: "+info.Name, findingURL(info.Location, url))
+	}
+
+	for _, secret := range matches.Secrets {
+		b.add(secret.Fingerprint, "secret: "+secret.Name, findingURL(secret.Location, url))
+	}
+
+	for _, jwt := range matches.JWTs {
+		b.add(jwt.Fingerprint, "jwt: "+jwt.Source, url)
+	}
+
+	for _, header := range matches.Headers {
+		b.add(header.Fingerprint, "header: "+header.Name, url)
+	}
+
+	for _, cookie := range matches.Cookies {
+		b.add(cookie.Fingerprint, "cookie: "+cookie.Name, url)
+	}
+
+	for _, cors := range matches.CORS {
+		b.add(cors.Fingerprint, "cors: "+cors.Name, url)
+	}
+
+	for _, technology := range matches.Technologies {
+		b.add(technology.Fingerprint, "technology: "+technology.Name, url)
+	}
+
+	for _, library := range matches.JSLibraries {
+		b.add(library.Fingerprint, "jslibrary: "+library.Name, url)
+	}
+
+	for _, host := range matches.Hosts {
+		b.add(host.Fingerprint, "host: "+host.Name, url)
+	}
+
+	for _, bucket := range matches.Buckets {
+		b.add(bucket.Fingerprint, "bucket: "+bucket.Name, url)
+	}
+
+	for _, api := range matches.APIs {
+		b.add(api.Fingerprint, "api: "+api.Method+" "+api.Path, url)
+	}
+
+	for _, reflection := range matches.Reflections {
+		b.add(reflection.Fingerprint, "reflection: "+reflection.Parameter, url)
+	}
+
+	for _, redirect := range matches.Redirects {
+		b.add(redirect.Fingerprint, "redirect: "+redirect.Parameter, url)
+	}
+
+	for _, exposure := range matches.Exposures {
+		b.add(exposure.Fingerprint, "exposure: "+exposure.Name, url)
+	}
+}
+
+// BaselineFromJSON returns the baseline of all the findings
+// in the JSON output of a scan.
+func BaselineFromJSON(r io.Reader, status, reason, expires string) (*scanner.Baseline, error) {
+	builder := &baselineBuilder{
+		status:  status,
+		reason:  reason,
+		expires: expires,
+		entries: []scanner.BaselineEntry{},
+		seen:    map[string]bool{},
+	}
+
+	if err := ReadJSONLines(r, builder.addMatches); err != nil {
+		return nil, err
+	}
+
+	return scanner.NewBaseline(builder.entries), nil
+}
+
+// CreateBaseline reads the JSON outputs of the scans (stdin if
+// no file is given) and writes the baseline of all their findings.
+func CreateBaseline(flags input.BaselineInput) error {
+	var reader io.Reader = os.Stdin
+
+	if len(flags.Files) != 0 {
+		data := []byte{}
+
+		for _, filename := range flags.Files {
+			content, err := os.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+
+			data = append(append(data, content...), '\n')
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	baseline, err := BaselineFromJSON(reader, flags.Status, flags.Reason, flags.Expires)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if flags.Output == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	return os.WriteFile(flags.Output, append(data, '\n'), fileUtils.Permission0644)
+}
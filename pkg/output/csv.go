@@ -0,0 +1,144 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// CSVWriter writes a CSV file for each type of
+// finding: output-cariddi/name.type.csv.
+type CSVWriter struct {
+	Dir      string
+	Name     string
+	findings []Finding
+}
+
+// csvHeader is the first record of the CSV files.
+var csvHeader = []string{ // nolint: gochecknoglobals
+	"name", "severity", "confidence", "host", "url", "match", "details", "fingerprint",
+}
+
+// NewCSVWriter returns a writer of the CSV files name.type.csv.
+func NewCSVWriter(name string) *CSVWriter {
+	return &CSVWriter{Dir: CariddiOutputFolder, Name: name}
+}
+
+// Start resets the findings.
+func (w *CSVWriter) Start(info *ScanInfo) error {
+	w.findings = []Finding{}
+	return nil
+}
+
+// Write collects a finding.
+func (w *CSVWriter) Write(finding Finding) error {
+	w.findings = append(w.findings, finding)
+	return nil
+}
+
+// Finish writes the CSV files.
+func (w *CSVWriter) Finish() error {
+	name := strings.TrimSuffix(w.Name, ".csv")
+
+	for _, kind := range findingKinds(w.findings) {
+		data, err := formatCSV(w.findings, kind)
+		if err != nil {
+			return err
+		}
+
+		if err := writeOutputFile(w.Dir, name, kind, "csv", []byte(data)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FormatCSV returns the CSV listing all the findings of a type
+// (secret, parameter, filetype...).
+func FormatCSV(results *scanner.Results, kind string) (string, error) {
+	return formatCSV(GetFindings(results), kind)
+}
+
+// formatCSV returns the CSV listing the findings of a type.
+func formatCSV(findings []Finding, kind string) (string, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return "", err
+	}
+
+	for _, f := range findings {
+		if f.Kind != kind {
+			continue
+		}
+
+		record := []string{f.Name, f.Severity, f.Confidence, f.Host, f.URL, f.Snippet, f.Message, f.Fingerprint}
+		for i := range record {
+			record[i] = csvCell(record[i])
+		}
+
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+
+	return buf.String(), w.Error()
+}
+
+// csvCell prevents a value found in a response from being
+// evaluated as a formula when the CSV is opened in a spreadsheet.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// findingKinds returns the types of the findings (the URLs
+// are not findings), in order of appearance.
+func findingKinds(findings []Finding) []string {
+	kinds := []string{}
+	seen := map[string]bool{}
+
+	for _, f := range findings {
+		if f.Kind != FindingURL && !seen[f.Kind] {
+			seen[f.Kind] = true
+			kinds = append(kinds, f.Kind)
+		}
+	}
+
+	return kinds
+}
@@ -0,0 +1,430 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	fileUtils "github.com/edoardottt/cariddi/internal/file"
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/input"
+)
+
+// Technology changes.
+const (
+	TechnologyAdded   = "added"
+	TechnologyRemoved = "removed"
+	TechnologyUpdated = "updated"
+)
+
+// DiffFinding struct.
+// Kind = the kind of finding (secret, parameter or path).
+// Name = the name of the rule matched.
+// Match = the string matched (secrets only).
+// URL = url in which the finding was found.
+// Severity = the severity of the finding.
+// Fingerprint = the stable fingerprint of the finding.
+type DiffFinding struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Match       string `json:"match,omitempty"`
+	URL         string `json:"url"`
+	Severity    string `json:"severity,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// TechnologyChange struct.
+// Host = the host running the technology.
+// Name = the name of the technology.
+// Change = added, removed or updated.
+// OldVersion, NewVersion = the versions detected in the two scans.
+type TechnologyChange struct {
+	Host       string `json:"host"`
+	Name       string `json:"name"`
+	Change     string `json:"change"`
+	OldVersion string `json:"old_version,omitempty"`
+	NewVersion string `json:"new_version,omitempty"`
+}
+
+// Diff struct.
+// It contains the changes between two scans.
+type Diff struct {
+	Old               string             `json:"old"`
+	New               string             `json:"new"`
+	NewURLs           []string           `json:"new_urls"`
+	RemovedURLs       []string           `json:"removed_urls"`
+	NewSecrets        []DiffFinding      `json:"new_secrets"`
+	ResolvedSecrets   []DiffFinding      `json:"resolved_secrets"`
+	NewEndpoints      []DiffFinding      `json:"new_endpoints"`
+	TechnologyChanges []TechnologyChange `json:"technology_changes"`
+}
+
+// snapshot is what a scan found, read from its JSON output.
+// Secrets and endpoints are identified by their fingerprint,
+// the technologies by host and name.
+type snapshot struct {
+	urls         map[string]bool
+	secrets      map[string]DiffFinding
+	endpoints    map[string]DiffFinding
+	technologies map[string]map[string]string
+}
+
+// newSnapshot returns an empty snapshot.
+func newSnapshot() *snapshot {
+	return &snapshot{
+		urls:         map[string]bool{},
+		secrets:      map[string]DiffFinding{},
+		endpoints:    map[string]DiffFinding{},
+		technologies: map[string]map[string]string{},
+	}
+}
+
+// add adds the findings of a JSON line to the snapshot.
+func (s *snapshot) add(data *JSONData) {
+	s.urls[data.URL] = true
+
+	if data.Matches == nil {
+		return
+	}
+
+	for _, secret := range data.Matches.Secrets {
+		s.secrets[secret.Fingerprint] = DiffFinding{
+			"secret", secret.Name, secret.Match, findingURL(secret.Location, data.URL),
+			secret.Severity, secret.Fingerprint,
+		}
+	}
+
+	for _, parameter := range data.Matches.Parameters {
+		s.endpoints[parameter.Fingerprint] = DiffFinding{
+			"parameter", parameter.Parameter.Parameter, "", data.URL, parameter.Severity, parameter.Fingerprint,
+		}
+	}
+
+	for _, path := range data.Matches.Paths {
+		s.endpoints[path.Fingerprint] = DiffFinding{"path", path.Name, "", data.URL, path.Severity, path.Fingerprint}
+	}
+
+	host := urlUtils.GetHost(data.URL)
+
+	for _, technology := range data.Matches.Technologies {
+		if s.technologies[host] == nil {
+			s.technologies[host] = map[string]string{}
+		}
+
+		// Prefer the detections with a version.
+		if version, ok := s.technologies[host][technology.Name]; !ok || version == "" {
+			s.technologies[host][technology.Name] = technology.Version
+		}
+	}
+}
+
+// readSnapshot reads the JSON output of a scan.
+func readSnapshot(filename string) (*snapshot, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := newSnapshot()
+	if err := ReadJSONLines(file, result.add); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// newFindings returns the findings in b and not in a,
+// sorted by url and name.
+func newFindings(a, b map[string]DiffFinding) []DiffFinding {
+	result := []DiffFinding{}
+
+	for fingerprint, finding := range b {
+		if _, ok := a[fingerprint]; !ok {
+			result = append(result, finding)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].URL != result[j].URL {
+			return result[i].URL < result[j].URL
+		}
+
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+
+		return result[i].Fingerprint < result[j].Fingerprint
+	})
+
+	return result
+}
+
+// newURLs returns the urls in b and not in a, sorted.
+func newURLs(a, b map[string]bool) []string {
+	result := []string{}
+
+	for url := range b {
+		if !a[url] {
+			result = append(result, url)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// technologyChanges returns the technologies added, removed
+// and updated between two scans, sorted by host and name.
+func technologyChanges(a, b map[string]map[string]string) []TechnologyChange {
+	result := []TechnologyChange{}
+
+	for host, technologies := range b {
+		for name, version := range technologies {
+			oldVersion, ok := a[host][name]
+
+			switch {
+			case !ok:
+				result = append(result, TechnologyChange{host, name, TechnologyAdded, "", version})
+			case oldVersion != version:
+				result = append(result, TechnologyChange{host, name, TechnologyUpdated, oldVersion, version})
+			}
+		}
+	}
+
+	for host, technologies := range a {
+		for name, version := range technologies {
+			if _, ok := b[host][name]; !ok {
+				result = append(result, TechnologyChange{host, name, TechnologyRemoved, version, ""})
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Host != result[j].Host {
+			return result[i].Host < result[j].Host
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// diffSnapshots returns the changes between two scans.
+func diffSnapshots(a, b *snapshot) *Diff {
+	return &Diff{
+		NewURLs:           newURLs(a.urls, b.urls),
+		RemovedURLs:       newURLs(b.urls, a.urls),
+		NewSecrets:        newFindings(a.secrets, b.secrets),
+		ResolvedSecrets:   newFindings(b.secrets, a.secrets),
+		NewEndpoints:      newFindings(a.endpoints, b.endpoints),
+		TechnologyChanges: technologyChanges(a.technologies, b.technologies),
+	}
+}
+
+// DiffScans returns the changes between the JSON outputs of two scans.
+func DiffScans(oldFile, newFile string) (*Diff, error) {
+	a, err := readSnapshot(oldFile)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := readSnapshot(newFile)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffSnapshots(a, b)
+	diff.Old, diff.New = oldFile, newFile
+
+	return diff, nil
+}
+
+// FormatDiffFinding returns a one line description of a finding.
+func FormatDiffFinding(finding DiffFinding) string {
+	result := finding.Kind + " " + finding.Name
+	if finding.Match != "" {
+		result += " " + finding.Match
+	}
+
+	result += " in " + finding.URL
+	if finding.Severity != "" {
+		result += " [" + finding.Severity + "]"
+	}
+
+	return result
+}
+
+// FormatTechnologyChange returns a one line description of
+// a technology change.
+func FormatTechnologyChange(change TechnologyChange) string {
+	version := func(v string) string {
+		if v == "" {
+			return "no version"
+		}
+
+		return v
+	}
+
+	result := change.Name + " " + change.Change + " on " + change.Host
+	switch change.Change {
+	case TechnologyAdded:
+		result += " (" + version(change.NewVersion) + ")"
+	case TechnologyRemoved:
+		result += " (" + version(change.OldVersion) + ")"
+	case TechnologyUpdated:
+		result += " (" + version(change.OldVersion) + " -> " + version(change.NewVersion) + ")"
+	}
+
+	return result
+}
+
+// diffSection is a titled list of changes.
+type diffSection struct {
+	title string
+	items []string
+	links bool
+}
+
+// sections returns the sections of the diff, in the order they are written.
+func (d *Diff) sections() []diffSection {
+	findings := func(list []DiffFinding) []string {
+		result := []string{}
+		for _, finding := range list {
+			result = append(result, FormatDiffFinding(finding))
+		}
+
+		return result
+	}
+
+	changes := []string{}
+	for _, change := range d.TechnologyChanges {
+		changes = append(changes, FormatTechnologyChange(change))
+	}
+
+	return []diffSection{
+		{"New URLs", d.NewURLs, true},
+		{"Removed URLs", d.RemovedURLs, true},
+		{"New secrets", findings(d.NewSecrets), false},
+		{"Resolved secrets", findings(d.ResolvedSecrets), false},
+		{"New endpoints", findings(d.NewEndpoints), false},
+		{"Technology changes", changes, false},
+	}
+}
+
+// FormatDiffMarkdown returns the diff as a Markdown document.
+func FormatDiffMarkdown(d *Diff) string {
+	var sb strings.Builder
+
+	sb.WriteString("# cariddi diff\n\n")
+	sb.WriteString("- Old scan: " + markdownEscaper.Replace(d.Old) + "\n")
+	sb.WriteString("- New scan: " + markdownEscaper.Replace(d.New) + "\n")
+
+	for _, section := range d.sections() {
+		sb.WriteString("\n## " + section.title + " (" + strconv.Itoa(len(section.items)) + ")\n\n")
+
+		if len(section.items) == 0 {
+			sb.WriteString("No changes.\n")
+			continue
+		}
+
+		for _, item := range section.items {
+			sb.WriteString("- " + markdownEscaper.Replace(item) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatDiffHTML returns the diff as an HTML page.
+func FormatDiffHTML(d *Diff) string {
+	var sb strings.Builder
+
+	sb.WriteString(bannerHTML)
+	sb.WriteString("<h3>Diff</h3><ul><li>Old scan: " + html.EscapeString(d.Old) + "</li>")
+	sb.WriteString("<li>New scan: " + html.EscapeString(d.New) + "</li></ul>")
+
+	for _, section := range d.sections() {
+		sb.WriteString("<h3>" + section.title + " (" + strconv.Itoa(len(section.items)) + ")</h3><ul>")
+
+		for _, item := range section.items {
+			item = html.EscapeString(item)
+			if section.links {
+				item = "<a target='_blank' href='" + item + "'>" + item + "</a>"
+			}
+
+			sb.WriteString("<li>" + item + "</li>")
+		}
+
+		sb.WriteString("</ul>")
+	}
+
+	sb.WriteString(bannerFooterHTML)
+	sb.WriteString("</body></html>\n")
+
+	return sb.String()
+}
+
+// CreateDiff compares the JSON outputs of two scans
+// and writes the diff in the format requested.
+func CreateDiff(flags input.DiffInput) error {
+	diff, err := DiffScans(flags.Files[0], flags.Files[1])
+	if err != nil {
+		return err
+	}
+
+	var result string
+
+	switch flags.Format {
+	case input.DiffMarkdown:
+		result = FormatDiffMarkdown(diff)
+	case input.DiffHTML:
+		result = FormatDiffHTML(diff)
+	default:
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		result = string(data) + "\n"
+	}
+
+	if flags.Output == "" {
+		fmt.Print(result)
+		return nil
+	}
+
+	return os.WriteFile(flags.Output, []byte(result), fileUtils.Permission0644)
+}
@@ -56,6 +56,8 @@ func PrintExamples() {
 	
 	cat urls | cariddi -e -ef endpoints_file (Hunt for custom endpoints)
 
+	cat urls | cariddi -e -pf paths_file (Hunt for juicy endpoints with custom path regexes)
+
 	cat urls | cariddi -s -sf secrets_file (Hunt for custom secrets)
 	
 	cat urls | cariddi -i forum,blog,community,open (Ignore urls containing these words)
@@ -86,5 +88,45 @@ func PrintExamples() {
 	
 	cat urls | cariddi -json (Print the output as JSON)
 	
-	cat urls | cariddi -sr (Store HTTP responses)`)
+	cat urls | cariddi -sr (Store HTTP responses)
+
+	cat urls | cariddi -s -redact -redact-hash (Mask secrets in every output, appending a fingerprint)
+
+	cat urls | cariddi -s -info -decode 2 (Decode encoded content up to depth 2 and hunt in it)
+
+	cat urls | cariddi -hdr (Hunt for missing, weak and information leaking headers)
+
+	cat urls | cariddi -cookies (Hunt for weak cookie attributes and values)
+
+	cat urls | cariddi -cors (Actively hunt for CORS misconfigurations)
+
+	cat urls | cariddi -tech -tf technologies.json (Detect the technologies used by the hosts with custom fingerprints)
+
+	cat urls | cariddi -js (Hunt for vulnerable JavaScript libraries)
+
+	cat urls | cariddi -hosts -ot target (Harvest the hostnames found and save them as an asset list)
+
+	cat urls | cariddi -cloud -cloud-check (Hunt for cloud storage buckets and check if they are publicly listable)
+
+	cat urls | cariddi -api -spec openapi.yaml -e (Parse the API specifications and use a local one as crawl seeds)
+
+	cat urls | cariddi -xss -d 1 (Actively hunt for XSS reflection points, one request per second)
+
+	cat urls | cariddi -redir -redir-url https://example.com/ (Verify the open redirects with a controlled URL)
+
+	cat urls | cariddi -backup -ext 1 (Probe the VCS files and the backup copies, validating the juicy files found)
+
+	cat urls | cariddi -s -err -info -min-severity medium (Report only the findings rated medium or above)
+
+	cariddi baseline -reason "Triaged" -expires 2026-12-31 -o baseline.json scan.json (Accept the findings of a -json scan)
+
+	cat urls | cariddi -s -e -baseline baseline.json (Report only the findings not in the baseline)
+
+	cariddi diff -format md -o changes.md last-week.json today.json (Track the attack surface changes between two scans)
+
+	cat urls | cariddi -s -e -err -osarif results (Write all the findings as SARIF for the code scanning dashboards)
+
+	cat urls | cariddi -s -e -tech -oj report.json (Write the scan metadata and all the findings into a JSON report)
+
+	cat urls | cariddi -s -e -err -ocsv findings -omd findings (Write the findings as CSV files by type and as Markdown)`)
 }
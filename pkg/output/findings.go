@@ -0,0 +1,384 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	urlUtils "github.com/edoardottt/cariddi/internal/url"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// Finding types.
+const (
+	FindingURL        = "url"
+	FindingSecret     = "secret"
+	FindingParameter  = "parameter"
+	FindingPath       = "path"
+	FindingFileType   = "filetype"
+	FindingError      = "error"
+	FindingInfo       = "info"
+	FindingJWT        = "jwt"
+	FindingHeader     = "header"
+	FindingCookie     = "cookie"
+	FindingCORS       = "cors"
+	FindingTechnology = "technology"
+	FindingJSLibrary  = "jslibrary"
+	FindingHost       = "host"
+	FindingBucket     = "bucket"
+	FindingAPI        = "api"
+	FindingReflection = "reflection"
+	FindingRedirect   = "redirect"
+	FindingExposure   = "exposure"
+)
+
+// Finding is a result of any type (an URL crawled or a finding) with
+// all the information needed to write it in every output format.
+// Kind = the type of the result (url, secret, parameter, filetype...).
+// Name = the name of the rule matched.
+// Message = a human readable description of the finding.
+// Label, Detail = the finding printed as "[ Label ] Detail" in the standard output.
+// Text = the finding as written in the TXT and HTML outputs.
+// Snippet = the evidence of the finding (if any).
+// Region = the position of the snippet in the response body (if known).
+// Result = the scanner result (e.g. scanner.SecretMatched, the URL for the URLs).
+type Finding struct {
+	Kind        string
+	Name        string
+	Description string
+	Message     string
+	Label       string
+	Detail      string
+	Text        string
+	URL         string
+	Host        string
+	Snippet     string
+	Region      *scanner.Region
+	Fingerprint string
+	Severity    string
+	Confidence  string
+	Result      interface{}
+}
+
+// newFinding returns the finding of a scanner finding.
+// The host is the one of the URL.
+func newFinding(elem interface {
+	scanner.Finding
+	scanner.Fingerprinted
+}, kind, name, description, message, url, snippet string) Finding {
+	return Finding{
+		Kind:        kind,
+		Name:        name,
+		Description: description,
+		Message:     message,
+		URL:         url,
+		Host:        urlUtils.GetHost(url),
+		Snippet:     snippet,
+		Fingerprint: elem.Fingerprint(),
+		Severity:    elem.Severity(),
+		Confidence:  elem.Confidence(),
+		Result:      elem,
+	}
+}
+
+// printed sets how the finding is printed in the standard output
+// and in the text files (the text is the detail if empty).
+func (f Finding) printed(label, detail, text string) Finding {
+	f.Label = label
+	f.Detail = detail
+	f.Text = text
+
+	if text == "" {
+		f.Text = detail
+	}
+
+	return f
+}
+
+// URLFinding returns the finding of an URL crawled.
+func URLFinding(url string) Finding {
+	return Finding{Kind: FindingURL, URL: url, Host: urlUtils.GetHost(url), Text: url, Result: url}
+}
+
+// secretDescription returns the description of a secret
+// definition (the name for the custom secrets).
+func secretDescription(name string) string {
+	for _, secret := range scanner.GetSecretRegexes() {
+		if secret.Name == name && secret.Description != name {
+			return secret.Description
+		}
+	}
+
+	return name + " exposed"
+}
+
+// parameterDescription returns the description of a juicy parameter
+// definition: the attacks it could be vulnerable to.
+func parameterDescription(name string) string {
+	for _, parameter := range scanner.GetJuicyParameters() {
+		if parameter.Parameter == name && len(parameter.Attacks) != 0 {
+			return "Juicy parameter, it could be vulnerable to: " + strings.Join(parameter.Attacks, ", ")
+		}
+	}
+
+	return "Juicy parameter"
+}
+
+// extensionDescription returns the description of a juicy extension definition.
+func extensionDescription(extension string) string {
+	for _, filetype := range scanner.GetExtensions() {
+		if filetype.Extension == extension {
+			return fmt.Sprintf("Juicy file extension .%s (level %d, 1 juicy - 7 not juicy)",
+				extension, filetype.Severity)
+		}
+	}
+
+	return "Juicy file extension ." + extension
+}
+
+// GetFindings returns all the findings of the results, type by type
+// (in the order of the TXT and HTML outputs).
+func GetFindings(results *scanner.Results) []Finding {
+	findings := []Finding{}
+
+	for _, elem := range results.Secrets {
+		rated := " in " + elem.URL + FormatRating(elem)
+		finding := newFinding(elem, FindingSecret, elem.Secret.Name, secretDescription(elem.Secret.Name),
+			elem.Secret.Name+" - "+elem.Match, elem.URL, elem.Match)
+		finding.Region = elem.Region
+		findings = append(findings, finding.printed(elem.Secret.Name, elem.Match+rated, finding.Message+rated))
+	}
+
+	for _, elem := range results.Endpoints {
+		for _, parameter := range elem.Parameters {
+			severity := scanner.ParameterSeverity(parameter)
+			findings = append(findings, Finding{
+				Kind:        FindingParameter,
+				Name:        parameter.Parameter,
+				Description: parameterDescription(parameter.Parameter),
+				Message:     FormatParameter(parameter),
+				URL:         elem.URL,
+				Host:        urlUtils.GetHost(elem.URL),
+				Fingerprint: scanner.ParameterFingerprint(elem.URL, parameter),
+				Severity:    severity,
+				Confidence:  parameter.Confidence,
+				Result:      scanner.EndpointMatched{Parameters: []scanner.Parameter{parameter}, URL: elem.URL},
+			}.printed(FormatParameter(parameter), " in "+elem.URL+FormatLevels(severity, parameter.Confidence),
+				FormatParameter(parameter)+" in "+elem.URL+FormatLevels(severity, parameter.Confidence)))
+		}
+
+		for _, path := range elem.Paths {
+			findings = append(findings, Finding{
+				Kind:        FindingPath,
+				Name:        path.Name,
+				Description: path.Category + " path: " + path.Name,
+				Message:     FormatPath(path),
+				URL:         elem.URL,
+				Host:        urlUtils.GetHost(elem.URL),
+				Fingerprint: scanner.PathFingerprint(elem.URL, path),
+				Severity:    path.Severity,
+				Confidence:  scanner.ConfidenceHigh,
+				Result:      scanner.EndpointMatched{Paths: []scanner.JuicyPath{path}, URL: elem.URL},
+			}.printed(FormatPath(path), " in "+elem.URL+FormatLevels(path.Severity, scanner.ConfidenceHigh),
+				FormatPath(path)+" in "+elem.URL+FormatLevels(path.Severity, scanner.ConfidenceHigh)))
+		}
+	}
+
+	for _, elem := range results.Extensions {
+		finding := newFinding(elem, FindingFileType, elem.Filetype.Extension,
+			extensionDescription(elem.Filetype.Extension), FormatExtension(elem)+" matched", elem.URL, "")
+		findings = append(findings, finding.printed(FormatExtension(elem), elem.URL+" matched!"+FormatRating(elem),
+			FormatExtension(elem)+" in "+elem.URL+FormatRating(elem)))
+	}
+
+	for _, elem := range results.Errors {
+		rated := " in " + elem.URL + FormatRating(elem)
+		finding := newFinding(elem, FindingError, elem.Error.ErrorName, elem.Error.ErrorName+" disclosed in the response",
+			elem.Error.ErrorName+" - "+elem.Match, elem.URL, elem.Match)
+		finding.Region = elem.Region
+		findings = append(findings, finding.printed(elem.Error.ErrorName, elem.Match+rated, finding.Message+rated))
+	}
+
+	for _, elem := range results.Infos {
+		rated := " in " + elem.URL + FormatRating(elem)
+		finding := newFinding(elem, FindingInfo, elem.Info.Name, elem.Info.Name+" disclosed in the response",
+			elem.Info.Name+" - "+elem.Match, elem.URL, elem.Match)
+		finding.Region = elem.Region
+		findings = append(findings, finding.printed(elem.Info.Name, elem.Match+rated, finding.Message+rated))
+	}
+
+	for _, elem := range results.JWTs {
+		finding := newFinding(elem, FindingJWT, "JWT",
+			"JSON Web Token found (with its weaknesses)", FormatJWT(elem), elem.URL, elem.Match)
+		findings = append(findings, finding.printed("JWT", finding.Message+" in "+elem.URL+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.Headers {
+		finding := newFinding(elem, FindingHeader, elem.Header.Name,
+			elem.Header.Description, FormatHeader(elem), elem.URL, elem.Match)
+		finding.Host = elem.Host
+		findings = append(findings, finding.printed(elem.Header.Name,
+			finding.Message+" in "+elem.Host+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.Cookies {
+		finding := newFinding(elem, FindingCookie, "Weak cookie",
+			"Cookie with weak attributes or values", FormatCookie(elem), elem.URL, elem.Match)
+		finding.Host = elem.Host
+		findings = append(findings, finding.printed("Cookie", finding.Message+" in "+elem.Host+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.CORS {
+		finding := newFinding(elem, FindingCORS, elem.CORS.Name,
+			"CORS misconfiguration: "+elem.CORS.Name, FormatCORS(elem), elem.URL, elem.Match)
+		findings = append(findings, finding.printed("CORS", finding.Message+" in "+elem.URL+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.Technologies {
+		finding := newFinding(elem, FindingTechnology, elem.Technology.Name,
+			"Technology detected: "+elem.Technology.Name, FormatTechnology(elem), elem.URL, elem.Match)
+		finding.Host = elem.Host
+		findings = append(findings, finding.printed(elem.Host, finding.Message, elem.Host+": "+finding.Message))
+	}
+
+	for _, elem := range results.JSLibraries {
+		finding := newFinding(elem, FindingJSLibrary, elem.JSLibrary.Name,
+			"Vulnerable JavaScript library: "+elem.JSLibrary.Name, FormatJSLibrary(elem), elem.URL, elem.Match)
+		findings = append(findings, finding.printed(elem.JSLibrary.Name,
+			finding.Message+" in "+elem.URL+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.Hosts {
+		finding := newFinding(elem, FindingHost, "Host",
+			"Hostname found", FormatHost(elem), elem.URL, elem.Host.Name)
+		findings = append(findings, finding.printed(elem.Host.Scope,
+			elem.Host.Name+" ("+elem.Host.Source+") in "+elem.URL+FormatRating(elem), finding.Message+FormatRating(elem)))
+	}
+
+	for _, elem := range results.Buckets {
+		finding := newFinding(elem, FindingBucket, elem.Bucket.Provider,
+			elem.Bucket.Provider+" bucket found", FormatBucket(elem), elem.URL, elem.Match)
+		findings = append(findings, finding.printed("Bucket", finding.Message+" in "+elem.URL+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.APIs {
+		finding := newFinding(elem, FindingAPI, elem.API.Source,
+			"API endpoint found ("+elem.API.Source+")", FormatAPI(elem), elem.URL, "")
+		findings = append(findings, finding.printed("API", finding.Message+" in "+elem.URL+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.Reflections {
+		finding := newFinding(elem, FindingReflection, elem.Reflection.Context,
+			"Reflection point in the "+elem.Reflection.Context+" context", FormatReflection(elem), elem.URL, elem.Match)
+		findings = append(findings, finding.printed(elem.Reflection.Severity,
+			finding.Message+" in "+elem.URL+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.Redirects {
+		finding := newFinding(elem, FindingRedirect, "Open redirect",
+			"Open redirect", FormatRedirect(elem), elem.URL, "")
+		findings = append(findings, finding.printed("Open Redirect",
+			finding.Message+" in "+elem.URL+FormatRating(elem), ""))
+	}
+
+	for _, elem := range results.Exposures {
+		finding := newFinding(elem, FindingExposure, elem.Exposure.Name,
+			elem.Exposure.Category+" file exposed: "+elem.Exposure.Name, FormatExposure(elem), elem.URL,
+			elem.Exposure.Evidence)
+		findings = append(findings, finding.printed(elem.Exposure.Severity,
+			finding.Message+" in "+elem.URL+FormatRating(elem), ""))
+	}
+
+	return findings
+}
+
+// groupTechnologies merges the technologies found on the same host
+// in a single finding, as they are printed in the text outputs.
+func groupTechnologies(findings []Finding) []Finding {
+	hosts := []string{}
+	technologies := map[string][]string{}
+
+	for _, f := range findings {
+		if _, ok := technologies[f.Host]; !ok {
+			hosts = append(hosts, f.Host)
+		}
+
+		technologies[f.Host] = append(technologies[f.Host], f.Detail)
+	}
+
+	result := []Finding{}
+
+	for _, host := range hosts {
+		detail := strings.Join(technologies[host], ", ")
+		result = append(result, Finding{Kind: FindingTechnology, Host: host}.printed(host, detail, host+": "+detail))
+	}
+
+	return result
+}
+
+// appendResult appends the scanner result of a finding to results.
+func appendResult(results *scanner.Results, f Finding) {
+	switch result := f.Result.(type) {
+	case string:
+		results.URLs = append(results.URLs, result)
+	case scanner.SecretMatched:
+		results.Secrets = append(results.Secrets, result)
+	case scanner.EndpointMatched:
+		results.Endpoints = append(results.Endpoints, result)
+	case scanner.FileTypeMatched:
+		results.Extensions = append(results.Extensions, result)
+	case scanner.ErrorMatched:
+		results.Errors = append(results.Errors, result)
+	case scanner.InfoMatched:
+		results.Infos = append(results.Infos, result)
+	case scanner.JWTMatched:
+		results.JWTs = append(results.JWTs, result)
+	case scanner.HeaderMatched:
+		results.Headers = append(results.Headers, result)
+	case scanner.CookieMatched:
+		results.Cookies = append(results.Cookies, result)
+	case scanner.CORSMatched:
+		results.CORS = append(results.CORS, result)
+	case scanner.TechnologyMatched:
+		results.Technologies = append(results.Technologies, result)
+	case scanner.JSLibraryMatched:
+		results.JSLibraries = append(results.JSLibraries, result)
+	case scanner.HostMatched:
+		results.Hosts = append(results.Hosts, result)
+	case scanner.BucketMatched:
+		results.Buckets = append(results.Buckets, result)
+	case scanner.APIMatched:
+		results.APIs = append(results.APIs, result)
+	case scanner.ReflectionMatched:
+		results.Reflections = append(results.Reflections, result)
+	case scanner.RedirectMatched:
+		results.Redirects = append(results.Redirects, result)
+	case scanner.ExposureMatched:
+		results.Exposures = append(results.Exposures, result)
+	}
+}
@@ -32,14 +32,32 @@ import "fmt"
 func PrintHelp() {
 	Banner()
 	fmt.Println(`Usage of cariddi:
+	-api
+		Parse the API specifications found (OpenAPI/Swagger, GraphQL introspection).
+	-backup
+		Probe the version control files and the backup copies (~, .bak, .old, .swp...) of the files crawled.
+	-baseline string
+		Suppress the accepted and false positive findings listed in this file (see cariddi baseline).
 	-c int
-		Concurrency level. (default 20)
+		Concurrency level, active checks included. (default 20)
 	-cache
 		Use the .cariddi_cache folder as cache.
+	-cloud
+		Hunt for cloud storage buckets (S3, GCS, Azure Blob, DigitalOcean Spaces).
+	-cloud-check
+		Check if the buckets found are publicly listable.
+	-cloud-endpoint string
+		Use a custom (path-style) endpoint for the bucket checks E.g. http://127.0.0.1:9000.
+	-cookies
+		Hunt for weak cookie attributes and values.
+	-cors
+		Actively hunt for CORS misconfigurations (crafted Origin headers).
 	-d int
 		Delay between a page crawled and another.
 	-debug
 		Print debug information while crawling.
+	-decode int
+		Decode the encoded content (base64, hex, URL-encoding, gzip, JWT) up to this depth and hunt in it.
 	-e	Hunt for juicy endpoints.
 	-ef string
 		Use an external file (txt, one per line) to use custom parameters for endpoints hunting.
@@ -54,35 +72,80 @@ func PrintHelp() {
 		Use custom headers for each request E.g. -headers "Cookie: auth=yes;;Client: type=2".
   	-headersfile string
 	  	Read from an external file custom headers (same format of headers flag).
+	-hdr
+		Hunt for missing, weak and information leaking headers.
+	-hosts
+		Harvest the hostnames found (links, bodies, CSP, CORS and certificates).
 	-json
 		Print the output as JSON in stdout.
 	-i string
 		Ignore the URL containing at least one of the elements of this array.
 	-info
-		Hunt for useful information in websites.
+		Hunt for useful information in websites and in document metadata.
 	-intensive
 		Crawl searching for resources matching 2nd level domain.
 	-it string
 		Ignore the URL containing at least one of the lines of this file.
+	-js
+		Hunt for vulnerable JavaScript libraries.
+	-jsf string
+		Use an external file (Retire.js format) to use a custom vulnerabilities database for JavaScript libraries hunting.
+	-min-severity string
+		Report only the findings with at least this severity (info, low, medium, high, critical).
+	-ocsv string
+		Write the findings into a CSV file for each type.
 	-oh string
 		Write the output into an HTML file.
+	-oj string
+		Write the final results into a JSON report file.
+	-omd string
+		Write the findings into a Markdown file.
+	-osarif string
+		Write the output into a SARIF (2.1.0) file.
 	-ot string
 		Write the output into a TXT file.
+	-pf string
+		Use an external file (txt, one per line) to use custom regexes for juicy paths hunting.
 	-plain
 		Print only the results.
 	-proxy string
 		Set a Proxy to be used (http and socks5 supported).
+	-redact
+		Mask the secrets, tokens and cookie values found in every output (stdout, files and stored responses).
+	-redact-hash
+		Append a hashed fingerprint to the redacted secrets.
+	-redir
+		Verify the open redirects (controlled URL in the redirect-like parameters).
+	-redir-url string
+		Use a custom controlled URL for the open redirects verification E.g. https://example.com/.
 	-rua
 		Use a random browser user agent on every request.
 	-s	Hunt for secrets.
 	-sf string
 		Use an external file (txt, one per line) to use custom regexes for secrets hunting.
+	-spec string
+		Use a local OpenAPI/Swagger specification (JSON or YAML) as crawl seeds.
 	-sr
 		Store HTTP responses.
 	-t int
 		Set timeout for the requests. (default 10)
+	-tech
+		Detect the technologies used by the hosts (CMS, frameworks, servers...).
+	-tf string
+		Use an external file (Wappalyzer format) to use custom fingerprints for technologies detection.
 	-ua
 		Use a custom User Agent.
 	-version
-		Print the version.`)
+		Print the version.
+	-xss
+		Actively hunt for XSS reflection points (harmless canary in the parameters).
+
+Commands:
+	baseline [-status string] [-reason string] [-expires string] [-o string] [results.json ...]
+		Turn the JSON output of a scan (stdin if no file is given) into a baseline.
+		Status can be accepted (default) or false-positive, the findings are reported
+		again after the expiry date (YYYY-MM-DD).
+	diff [-format string] [-o string] old.json new.json
+		Compare the JSON outputs of two scans: new and removed URLs, new and resolved secrets,
+		new endpoints and technology changes. Format can be json (default), md or html.`)
 }
@@ -27,108 +27,76 @@ along with this program.  If not, see http://www.gnu.org/licenses/.
 package output
 
 import (
-	"log"
-	"os"
-
-	fileUtils "github.com/edoardottt/cariddi/internal/file"
+	"html"
+	"strings"
 )
 
-// BannerHTML appends the initial banner to html file.
-func BannerHTML(filename string) {
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, fileUtils.Permission0644)
-	if err != nil {
-		log.Println(err)
-		os.Exit(1)
-	}
-
-	_, err = file.WriteString(`<html><body><div style='background-color:#4adeff;color:white'><h1>Cariddi</h1>
+const (
+	bannerHTML = `<html><body><div style='background-color:#4adeff;color:white'><h1>Cariddi</h1>
 	<ul><li><a href='https://github.com/edoardottt/cariddi'>github.com/edoardottt/cariddi</a></li>
 	<li>edoardottt, <a href='https://www.edoardoottavianelli.it'>edoardoottavianelli.it</a></li>
-	<li>Released under <a href='http://www.gnu.org/licenses/gpl-3.0.html'>GPLv3 License</a></li></ul></div>`)
-	if err != nil {
-		log.Fatal(err)
-	}
+	<li>Released under <a href='http://www.gnu.org/licenses/gpl-3.0.html'>GPLv3 License</a></li></ul></div>`
+	bannerFooterHTML = `<div style='background-color:#4adeff;color:white'>
+	<ul><li><a href='https://github.com/edoardottt/cariddi'>Contribute to cariddi</a></li>
+	<li>Released under <a href='http://www.gnu.org/licenses/gpl-3.0.html'>GPLv3 License</a></li></ul></div>`
+)
 
-	file.Close()
+// HTMLWriter writes the results into an HTML file with
+// a list for each section: output-cariddi/name.html.
+// The sections enabled are written even if empty.
+type HTMLWriter struct {
+	Dir      string
+	Name     string
+	Sections []string
+	findings []Finding
 }
 
-// AppendOutputToHTML appends the output to html file.
-func AppendOutputToHTML(output string, status string, filename string, isLink bool) {
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, fileUtils.Permission0644)
-	if err != nil {
-		log.Println(err)
-		os.Exit(1)
-	}
-
-	if isLink {
-		var statusColor string
-
-		if status != "" {
-			if string(status[0]) == "2" || string(status[0]) == "3" {
-				statusColor = "<p style='color:green;display:inline'>" + status + "</p>"
-			} else {
-				statusColor = "<p style='color:red;display:inline'>" + status + "</p>"
-			}
-		} else {
-			statusColor = status
-		}
-
-		if _, err := file.WriteString("<li><a target='_blank' href='" + output + "'>" + output +
-			"</a> " + statusColor + "</li>"); err != nil {
-			log.Fatal(err)
-		}
-	} else {
-		if _, err := file.WriteString("<li>" + output + "</li>"); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	file.Close()
+// NewHTMLWriter returns a writer of the HTML file name.html.
+func NewHTMLWriter(name string, sections []string) *HTMLWriter {
+	return &HTMLWriter{Dir: CariddiOutputFolder, Name: name, Sections: sections}
 }
 
-// HeaderHTML appends the html header.
-func HeaderHTML(header string, filename string) {
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, fileUtils.Permission0644)
-	if err != nil {
-		log.Println(err)
-		os.Exit(1)
-	}
+// Start resets the findings.
+func (w *HTMLWriter) Start(info *ScanInfo) error {
+	w.findings = []Finding{}
+	return nil
+}
 
-	if _, err := file.WriteString("<h3>" + header + "</h3><ul>"); err != nil {
-		log.Fatal(err)
-	}
+// Write collects a finding.
+func (w *HTMLWriter) Write(finding Finding) error {
+	w.findings = append(w.findings, finding)
+	return nil
+}
 
-	file.Close()
+// Finish writes the HTML file.
+func (w *HTMLWriter) Finish() error {
+	return writeOutputFile(w.Dir, w.Name, "", "html", []byte(FormatHTML(w.Sections, w.findings)))
 }
 
-// FooterHTML appends the footer.
-func FooterHTML(filename string) {
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, fileUtils.Permission0644)
-	if err != nil {
-		log.Println(err)
-		os.Exit(1)
-	}
+// FormatHTML returns the HTML page listing the findings section by section.
+// The URLs are links, all the values found are escaped.
+func FormatHTML(sections []string, findings []Finding) string {
+	var sb strings.Builder
 
-	if _, err := file.WriteString("</ul>"); err != nil {
-		log.Fatal(err)
-	}
+	sb.WriteString(bannerHTML)
 
-	file.Close()
-}
+	enabled, bySection := sectionFindings(sections, findings)
+	for _, s := range enabled {
+		sb.WriteString("<h3>" + s.title + "</h3><ul>")
 
-// BannerFooterHTML appends the final footer.
-func BannerFooterHTML(filename string) {
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, fileUtils.Permission0644)
-	if err != nil {
-		log.Println(err)
-	}
+		for _, f := range bySection[s.name] {
+			if f.Kind == FindingURL {
+				url := html.EscapeString(f.URL)
+				sb.WriteString("<li><a target='_blank' href='" + url + "'>" + url + "</a></li>")
+			} else {
+				sb.WriteString("<li>" + html.EscapeString(f.Text) + "</li>")
+			}
+		}
 
-	_, err = file.WriteString(`<div style='background-color:#4adeff;color:white'>
-	<ul><li><a href='https://github.com/edoardottt/cariddi'>Contribute to cariddi</a></li>
-	<li>Released under <a href='http://www.gnu.org/licenses/gpl-3.0.html'>GPLv3 License</a></li></ul></div>`)
-	if err != nil {
-		log.Fatal(err)
+		sb.WriteString("</ul>")
 	}
 
-	file.Close()
+	sb.WriteString(bannerFooterHTML)
+
+	return sb.String()
 }
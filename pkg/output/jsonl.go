@@ -27,7 +27,12 @@ along with this program.  If not, see http://www.gnu.org/licenses/.
 package output
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 
@@ -35,6 +40,9 @@ import (
 	"github.com/gocolly/colly"
 )
 
+// maxJSONLineSize is the maximum size of a JSON line read.
+const maxJSONLineSize = 64 << 20
+
 type JSONData struct {
 	URL           string          `json:"url"`
 	Method        string          `json:"method"`
@@ -48,35 +56,171 @@ type JSONData struct {
 }
 
 type MatcherResults struct {
-	FileType   *scanner.FileType   `json:"filetype,omitempty"`
-	Parameters []scanner.Parameter `json:"parameters,omitempty"`
-	Errors     []MatcherResult     `json:"errors,omitempty"`
-	Infos      []MatcherResult     `json:"infos,omitempty"`
-	Secrets    []MatcherResult     `json:"secrets,omitempty"`
+	FileType     *FileTypeResult    `json:"filetype,omitempty"`
+	Files        []FileTypeResult   `json:"files,omitempty"`
+	Parameters   []ParameterResult  `json:"parameters,omitempty"`
+	Paths        []PathResult       `json:"paths,omitempty"`
+	Errors       []MatcherResult    `json:"errors,omitempty"`
+	Infos        []MatcherResult    `json:"infos,omitempty"`
+	Secrets      []MatcherResult    `json:"secrets,omitempty"`
+	JWTs         []JWTResult        `json:"jwts,omitempty"`
+	Headers      []HeaderResult     `json:"headers,omitempty"`
+	Cookies      []CookieResult     `json:"cookies,omitempty"`
+	CORS         []CORSResult       `json:"cors,omitempty"`
+	Technologies []TechnologyResult `json:"technologies,omitempty"`
+	JSLibraries  []JSLibraryResult  `json:"jslibs,omitempty"`
+	Hosts        []HostResult       `json:"hosts,omitempty"`
+	Buckets      []BucketResult     `json:"buckets,omitempty"`
+	APIs         []APIResult        `json:"apis,omitempty"`
+	Reflections  []ReflectionResult `json:"reflections,omitempty"`
+	Redirects    []RedirectResult   `json:"redirects,omitempty"`
+	Exposures    []ExposureResult   `json:"exposures,omitempty"`
 }
 
 type MatcherResult struct {
-	Name  string `json:"name"`
-	Match string `json:"match"`
-}
-
-func GetJSONString(
-	r *colly.Response,
-	secrets []scanner.SecretMatched,
-	parameters []scanner.Parameter,
-	filetype *scanner.FileType,
-	errors []scanner.ErrorMatched,
-	infos []scanner.InfoMatched,
-) ([]byte, error) {
+	Name        string `json:"name"`
+	Match       string `json:"match"`
+	Severity    string `json:"severity"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	Location    string `json:"location,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+type ParameterResult struct {
+	scanner.Parameter
+	URL string `json:"url,omitempty"`
+}
+
+type PathResult struct {
+	scanner.JuicyPath
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+type HeaderResult struct {
+	Name        string `json:"name"`
+	Header      string `json:"header"`
+	Match       string `json:"match,omitempty"`
+	Severity    string `json:"severity"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	Host        string `json:"host,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+type CookieResult struct {
+	scanner.Cookie
+	Severity    string `json:"severity"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	Host        string `json:"host,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+type CORSResult struct {
+	scanner.CORS
+	Match       string `json:"match"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+type TechnologyResult struct {
+	scanner.Technology
+	Severity    string `json:"severity"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	Host        string `json:"host,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+type JSLibraryResult struct {
+	scanner.JSLibrary
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+type HostResult struct {
+	scanner.Host
+	Severity    string `json:"severity"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+type BucketResult struct {
+	scanner.Bucket
+	Severity    string `json:"severity"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+type APIResult struct {
+	scanner.APIEndpoint
+	Severity    string `json:"severity"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	Spec        string `json:"spec,omitempty"`
+}
+
+type ReflectionResult struct {
+	scanner.Reflection
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+type RedirectResult struct {
+	scanner.Redirect
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+type ExposureResult struct {
+	scanner.Exposure
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+// FileTypeResult reports the 1 (juicy) to 7 (not juicy) severity
+// of the extension next to its severity level.
+type FileTypeResult struct {
+	Extension     string `json:"extension"`
+	Severity      int    `json:"severity"`
+	SeverityLevel string `json:"severity_level"`
+	Confidence    string `json:"confidence"`
+	Fingerprint   string `json:"fingerprint"`
+	Signal        string `json:"signal,omitempty"`
+	Location      string `json:"location,omitempty"`
+	URL           string `json:"url,omitempty"`
+}
+
+type JWTResult struct {
+	Match  string `json:"match"`
+	Source string `json:"source"`
+	scanner.JWT
+	Severity    string `json:"severity"`
+	Confidence  string `json:"confidence"`
+	Fingerprint string `json:"fingerprint"`
+	URL         string `json:"url,omitempty"`
+}
+
+// GetJSONString returns the JSON line describing a response
+// and all the matches found in it.
+func GetJSONString(r *colly.Response, matches *scanner.Results) ([]byte, error) {
 	// Parse response headers
 	headers := r.Headers
 	contentTypes := (*headers)["Content-Type"]
 	contentLengths := (*headers)["Content-Length"]
 	contentType := ""
 	contentLength := 0
-	errorList := []MatcherResult{}
-	infoList := []MatcherResult{}
-	secretList := []MatcherResult{}
+	target := r.Request.URL.String()
 
 	// Set content type
 	if len(contentTypes) > 0 {
@@ -99,36 +243,12 @@ func GetJSONString(
 	// Parse lines from body
 	lines := len(strings.Split(string(r.Body), "\n"))
 
-	// Process secrets
-	for _, secret := range secrets {
-		secretMatch := MatcherResult{secret.Secret.Name, secret.Match}
-		secretList = append(secretList, secretMatch)
-	}
-
-	// Process infos
-	for _, info := range infos {
-		infoMatch := MatcherResult{info.Info.Name, info.Match}
-		infoList = append(infoList, infoMatch)
-	}
-
-	// Process error list
-	for _, error := range errors {
-		errorMatch := MatcherResult{error.Error.ErrorName, error.Match}
-		errorList = append(errorList, errorMatch)
-	}
-
 	// Construct matcher results
-	matcherResults := &MatcherResults{
-		FileType:   filetype,
-		Parameters: parameters,
-		Errors:     errorList,
-		Infos:      infoList,
-		Secrets:    secretList,
-	}
+	matcherResults := newMatcherResults(matches, target, false)
 
 	// Construct JSON response
 	resp := &JSONData{
-		URL:           r.Request.URL.String(),
+		URL:           target,
 		Method:        r.Request.Method,
 		StatusCode:    r.StatusCode,
 		Words:         words,
@@ -140,20 +260,7 @@ func GetJSONString(
 	}
 
 	// Set empty data if no matches to bridge the omitempty gap for empty structs
-	var (
-		isFileTypeNill    = false
-		isParametersEmpty = len(parameters) == 0
-		isErrorsEmpty     = len(errorList) == 0
-		isInfoEmpty       = len(infoList) == 0
-		isSecretsEmpty    = len(secretList) == 0
-	)
-
-	if (*filetype == scanner.FileType{}) {
-		matcherResults.FileType = nil
-		isFileTypeNill = true
-	}
-
-	if isFileTypeNill && isParametersEmpty && isErrorsEmpty && isInfoEmpty && isSecretsEmpty {
+	if matcherResults.empty() {
 		resp.Matches = nil
 	}
 
@@ -165,3 +272,312 @@ func GetJSONString(
 
 	return jsonOutput, nil
 }
+
+// newMatcherResults converts the matches into their JSON representation.
+// The matches of a JSON line (found in the response target) report only the
+// location of the files inside an archive, while the matches of a report
+// (found across the whole scan) carry their URL and host.
+func newMatcherResults(matches *scanner.Results, target string, report bool) *MatcherResults {
+	errorList := []MatcherResult{}
+	infoList := []MatcherResult{}
+	secretList := []MatcherResult{}
+	jwtList := []JWTResult{}
+	headerList := []HeaderResult{}
+	cookieList := []CookieResult{}
+	corsList := []CORSResult{}
+	technologyList := []TechnologyResult{}
+	jsLibraryList := []JSLibraryResult{}
+	hostList := []HostResult{}
+	bucketList := []BucketResult{}
+	apiList := []APIResult{}
+	reflectionList := []ReflectionResult{}
+	redirectList := []RedirectResult{}
+	exposureList := []ExposureResult{}
+	parameters := []ParameterResult{}
+	paths := []PathResult{}
+	filetype := &FileTypeResult{}
+	fileList := []FileTypeResult{}
+
+	// reportValue returns value only for the matches of a report.
+	reportValue := func(value string) string {
+		if report {
+			return value
+		}
+
+		return ""
+	}
+
+	// Process secrets
+	for _, secret := range matches.Secrets {
+		secretMatch := MatcherResult{
+			Name:        secret.Secret.Name,
+			Match:       secret.Match,
+			Severity:    secret.Severity(),
+			Confidence:  secret.Confidence(),
+			Fingerprint: secret.Fingerprint(),
+			Location:    location(target, secret.URL),
+			URL:         reportValue(secret.URL),
+		}
+		secretList = append(secretList, secretMatch)
+	}
+
+	// Process infos
+	for _, info := range matches.Infos {
+		infoMatch := MatcherResult{
+			Name:        info.Info.Name,
+			Match:       info.Match,
+			Severity:    info.Severity(),
+			Confidence:  info.Confidence(),
+			Fingerprint: info.Fingerprint(),
+			Location:    location(target, info.URL),
+			URL:         reportValue(info.URL),
+		}
+		infoList = append(infoList, infoMatch)
+	}
+
+	// Process error list
+	for _, error := range matches.Errors {
+		errorMatch := MatcherResult{
+			Name:        error.Error.ErrorName,
+			Match:       error.Match,
+			Severity:    error.Severity(),
+			Confidence:  error.Confidence(),
+			Fingerprint: error.Fingerprint(),
+			Location:    location(target, error.URL),
+			URL:         reportValue(error.URL),
+		}
+		errorList = append(errorList, errorMatch)
+	}
+
+	// Process JWT list
+	for _, jwt := range matches.JWTs {
+		jwtMatch := JWTResult{
+			jwt.Match, jwt.Source, jwt.JWT, jwt.Severity(), jwt.Confidence(), jwt.Fingerprint(),
+			reportValue(jwt.URL),
+		}
+		jwtList = append(jwtList, jwtMatch)
+	}
+
+	// Process header list
+	for _, header := range matches.Headers {
+		headerMatch := HeaderResult{
+			header.Header.Name, header.Header.HTTPHeader, header.Match,
+			header.Severity(), header.Confidence(), header.Fingerprint(),
+			reportValue(header.Host), reportValue(header.URL),
+		}
+		headerList = append(headerList, headerMatch)
+	}
+
+	// Process cookie list
+	for _, cookie := range matches.Cookies {
+		cookieList = append(cookieList, CookieResult{
+			cookie.Cookie, cookie.Severity(), cookie.Confidence(), cookie.Fingerprint(),
+			reportValue(cookie.Host), reportValue(cookie.URL),
+		})
+	}
+
+	// Process CORS list
+	for _, cors := range matches.CORS {
+		corsList = append(corsList, CORSResult{
+			cors.CORS, cors.Match, cors.Confidence(), cors.Fingerprint(), reportValue(cors.URL),
+		})
+	}
+
+	// Process technology list
+	for _, technology := range matches.Technologies {
+		technologyList = append(technologyList, TechnologyResult{
+			technology.Technology, technology.Severity(), technology.Confidence(), technology.Fingerprint(),
+			reportValue(technology.Host), reportValue(technology.URL),
+		})
+	}
+
+	// Process JavaScript library list
+	for _, library := range matches.JSLibraries {
+		result := JSLibraryResult{library.JSLibrary, library.Confidence(), library.Fingerprint(), reportValue(library.URL)}
+		result.Severity = library.Severity()
+		jsLibraryList = append(jsLibraryList, result)
+	}
+
+	// Process host list
+	for _, host := range matches.Hosts {
+		hostList = append(hostList, HostResult{
+			host.Host, host.Severity(), host.Confidence(), host.Fingerprint(), reportValue(host.URL),
+		})
+	}
+
+	// Process bucket list
+	for _, bucket := range matches.Buckets {
+		bucketList = append(bucketList, BucketResult{
+			bucket.Bucket, bucket.Severity(), bucket.Confidence(), bucket.Fingerprint(), reportValue(bucket.URL),
+		})
+	}
+
+	// Process API list
+	for _, api := range matches.APIs {
+		apiList = append(apiList, APIResult{
+			api.API, api.Severity(), api.Confidence(), api.Fingerprint(), reportValue(api.URL),
+		})
+	}
+
+	// Process reflection list
+	for _, reflection := range matches.Reflections {
+		reflectionList = append(reflectionList, ReflectionResult{
+			reflection.Reflection, reflection.Confidence(), reflection.Fingerprint(), reportValue(reflection.URL),
+		})
+	}
+
+	// Process redirect list
+	for _, redirect := range matches.Redirects {
+		redirectList = append(redirectList, RedirectResult{
+			redirect.Redirect, redirect.Confidence(), redirect.Fingerprint(), reportValue(redirect.URL),
+		})
+	}
+
+	// Process exposure list
+	for _, exposure := range matches.Exposures {
+		exposureList = append(exposureList, ExposureResult{
+			exposure.Exposure, exposure.Confidence(), exposure.Fingerprint(), reportValue(exposure.URL),
+		})
+	}
+
+	// Process parameters
+	for _, endpoint := range matches.Endpoints {
+		for _, parameter := range endpoint.Parameters {
+			parameter.Severity = scanner.ParameterSeverity(parameter)
+			parameter.Fingerprint = scanner.ParameterFingerprint(endpoint.URL, parameter)
+			parameters = append(parameters, ParameterResult{parameter, reportValue(endpoint.URL)})
+		}
+
+		for _, path := range endpoint.Paths {
+			paths = append(paths, PathResult{
+				path, scanner.ConfidenceHigh, scanner.PathFingerprint(endpoint.URL, path), reportValue(endpoint.URL),
+			})
+		}
+	}
+
+	// Process filetype (the files inside an archive and all
+	// the files of a report are listed apart)
+	for _, extension := range matches.Extensions {
+		result := FileTypeResult{
+			Extension:     extension.Filetype.Extension,
+			Severity:      extension.Filetype.Severity,
+			SeverityLevel: extension.Severity(),
+			Confidence:    extension.Confidence(),
+			Fingerprint:   extension.Fingerprint(),
+			Signal:        extension.Signal,
+			Location:      location(target, extension.URL),
+			URL:           reportValue(extension.URL),
+		}
+		if result.Location != "" || report {
+			fileList = append(fileList, result)
+		} else if (*filetype == FileTypeResult{}) {
+			filetype = &result
+		}
+	}
+
+	if (*filetype == FileTypeResult{}) {
+		filetype = nil
+	}
+
+	return &MatcherResults{
+		FileType:     filetype,
+		Files:        fileList,
+		Parameters:   parameters,
+		Paths:        paths,
+		Errors:       errorList,
+		Infos:        infoList,
+		Secrets:      secretList,
+		JWTs:         jwtList,
+		Headers:      headerList,
+		Cookies:      cookieList,
+		CORS:         corsList,
+		Technologies: technologyList,
+		JSLibraries:  jsLibraryList,
+		Hosts:        hostList,
+		Buckets:      bucketList,
+		APIs:         apiList,
+		Reflections:  reflectionList,
+		Redirects:    redirectList,
+		Exposures:    exposureList,
+	}
+}
+
+// empty returns true if there are no matches.
+func (m *MatcherResults) empty() bool {
+	return m.FileType == nil && len(m.Files) == 0 && len(m.Parameters) == 0 && len(m.Paths) == 0 &&
+		len(m.Errors) == 0 && len(m.Infos) == 0 && len(m.Secrets) == 0 && len(m.JWTs) == 0 &&
+		len(m.Headers) == 0 && len(m.Cookies) == 0 && len(m.CORS) == 0 && len(m.Technologies) == 0 &&
+		len(m.JSLibraries) == 0 && len(m.Hosts) == 0 && len(m.Buckets) == 0 && len(m.APIs) == 0 &&
+		len(m.Reflections) == 0 && len(m.Redirects) == 0 && len(m.Exposures) == 0
+}
+
+// JSONLWriter prints a JSON line for each response while crawling.
+type JSONLWriter struct {
+	Out io.Writer
+}
+
+// NewJSONLWriter returns a writer printing the JSON lines into the standard output.
+func NewJSONLWriter() *JSONLWriter {
+	return &JSONLWriter{Out: os.Stdout}
+}
+
+// WriteResponse prints the JSON line of a response.
+func (w *JSONLWriter) WriteResponse(r *colly.Response, matches *scanner.Results) error {
+	line, err := GetJSONString(r, matches)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w.Out, string(line))
+
+	return err
+}
+
+// Start does nothing, the JSON lines are printed while crawling.
+func (w *JSONLWriter) Start(info *ScanInfo) error {
+	return nil
+}
+
+// Write does nothing, the JSON lines are printed while crawling.
+func (w *JSONLWriter) Write(finding Finding) error {
+	return nil
+}
+
+// Finish does nothing, the JSON lines are printed while crawling.
+func (w *JSONLWriter) Finish() error {
+	return nil
+}
+
+// location returns where a match was found if it's not the response
+// itself (a file inside an archive: archive-url!/inner/path).
+func location(target, matchURL string) string {
+	if matchURL == target || !strings.HasPrefix(matchURL, target+scanner.ArchiveSeparator) {
+		return ""
+	}
+
+	return matchURL
+}
+
+// ReadJSONLines reads the JSON output of a scan (a JSON line for each
+// response) and calls handle on every line. The lines that are not
+// JSON objects (e.g. the banner) are skipped.
+func ReadJSONLines(r io.Reader, handle func(data *JSONData)) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxJSONLineSize)
+
+	for line := 1; sc.Scan(); line++ {
+		text := bytes.TrimSpace(sc.Bytes())
+		if !bytes.HasPrefix(text, []byte("{")) {
+			continue
+		}
+
+		data := &JSONData{}
+		if err := json.Unmarshal(text, data); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		handle(data)
+	}
+
+	return sc.Err()
+}
@@ -61,9 +61,20 @@ func TestJSONOutput(t *testing.T) {
 			Attacks:   []string{},
 		},
 	}
-	filetype := &scanner.FileType{
-		Extension: "pdf",
-		Severity:  7,
+	endpoints := []scanner.EndpointMatched{
+		{
+			Parameters: parameters,
+			URL:        "http://test.com?id=5",
+		},
+	}
+	extensions := []scanner.FileTypeMatched{
+		{
+			Filetype: scanner.FileType{
+				Extension: "pdf",
+				Severity:  7,
+			},
+			URL: "http://test.com.pdf?id=5",
+		},
 	}
 	errors := []scanner.ErrorMatched{
 		{
@@ -85,6 +96,20 @@ func TestJSONOutput(t *testing.T) {
 			Match: "its my pleasure to inform you on this great day",
 		},
 	}
+	jwts := []scanner.JWTMatched{
+		{
+			JWT: scanner.JWT{
+				Algorithm: "none",
+				Type:      "JWT",
+				Issuer:    "cariddi",
+				Claims:    map[string]interface{}{"iss": "cariddi"},
+				Issues:    []string{scanner.JWTAlgNone, scanner.JWTNoExpiration},
+			},
+			URL:    "http://test.com?id=5",
+			Source: scanner.SourceCookie,
+			Match:  "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0.eyJpc3MiOiJjYXJpZGRpIn0.",
+		},
+	}
 	req := colly.Request{}
 	u, _ := url.Parse("http://test.com.pdf?id=5")
 	req.Method = "GET"
@@ -105,90 +130,86 @@ func TestJSONOutput(t *testing.T) {
 		Headers:    &headersNoContent,
 	}
 	tests := []struct {
-		name       string
-		r          *colly.Response
-		secrets    []scanner.SecretMatched
-		filetype   *scanner.FileType
-		parameters []scanner.Parameter
-		errors     []scanner.ErrorMatched
-		infos      []scanner.InfoMatched
-		want       string
+		name    string
+		r       *colly.Response
+		matches *scanner.Results
+		want    string
 	}{
 		{
-			name:       "test_all_findings",
-			r:          resp,
-			secrets:    secrets,
-			parameters: parameters,
-			filetype:   filetype,
-			errors:     errors,
-			infos:      infos,
-			want:       `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"filetype":{"extension":"pdf","severity":7},"parameters":[{"name":"id","attacks":[]}],"errors":[{"name":"MySQL error","match":"it is a MySQL error happening"}],"infos":[{"name":"info1","match":"its my pleasure to inform you on this great day"}],"secrets":[{"name":"mysecret","match":"it's a random day for my secret regex to be found"}]}}`, //nolint:lll
+			name: "test_all_findings",
+			r:    resp,
+			matches: &scanner.Results{
+				Secrets:    secrets,
+				Endpoints:  endpoints,
+				Extensions: extensions,
+				Errors:     errors,
+				Infos:      infos,
+			},
+			want: `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"filetype":{"extension":"pdf","severity":7,"severity_level":"info","confidence":"low","fingerprint":"ac7b157800f67f7a"},"parameters":[{"name":"id","attacks":[],"severity":"info","fingerprint":"175333752d137ec9"}],"errors":[{"name":"MySQL error","match":"it is a MySQL error happening","severity":"medium","confidence":"medium","fingerprint":"697095c9016ed0e4"}],"infos":[{"name":"info1","match":"its my pleasure to inform you on this great day","severity":"info","confidence":"medium","fingerprint":"2655ac7af3c8ce06"}],"secrets":[{"name":"mysecret","match":"it's a random day for my secret regex to be found","severity":"high","confidence":"high","fingerprint":"a22ca0cab423acf7"}]}}`, //nolint:lll
 		},
 		{
-			name:       "test_all_findings_nocontent",
-			r:          resp2,
-			secrets:    secrets,
-			parameters: parameters,
-			filetype:   filetype,
-			errors:     errors,
-			infos:      infos,
-			want:       `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"matches":{"filetype":{"extension":"pdf","severity":7},"parameters":[{"name":"id","attacks":[]}],"errors":[{"name":"MySQL error","match":"it is a MySQL error happening"}],"infos":[{"name":"info1","match":"its my pleasure to inform you on this great day"}],"secrets":[{"name":"mysecret","match":"it's a random day for my secret regex to be found"}]}}`, //nolint:lll
+			name: "test_all_findings_nocontent",
+			r:    resp2,
+			matches: &scanner.Results{
+				Secrets:    secrets,
+				Endpoints:  endpoints,
+				Extensions: extensions,
+				Errors:     errors,
+				Infos:      infos,
+			},
+			want: `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"matches":{"filetype":{"extension":"pdf","severity":7,"severity_level":"info","confidence":"low","fingerprint":"ac7b157800f67f7a"},"parameters":[{"name":"id","attacks":[],"severity":"info","fingerprint":"175333752d137ec9"}],"errors":[{"name":"MySQL error","match":"it is a MySQL error happening","severity":"medium","confidence":"medium","fingerprint":"697095c9016ed0e4"}],"infos":[{"name":"info1","match":"its my pleasure to inform you on this great day","severity":"info","confidence":"medium","fingerprint":"2655ac7af3c8ce06"}],"secrets":[{"name":"mysecret","match":"it's a random day for my secret regex to be found","severity":"high","confidence":"high","fingerprint":"a22ca0cab423acf7"}]}}`, //nolint:lll
 		},
 		{
-			name:       "test_no_findings",
-			r:          resp,
-			secrets:    []scanner.SecretMatched{},
-			parameters: []scanner.Parameter{},
-			filetype:   &scanner.FileType{},
-			errors:     []scanner.ErrorMatched{},
-			infos:      []scanner.InfoMatched{},
-			want:       `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128}`, //nolint: all
+			name:    "test_no_findings",
+			r:       resp,
+			matches: &scanner.Results{},
+			want:    `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128}`, //nolint: all
 		},
 		{
-			name:       "test_only_secrets",
-			r:          resp,
-			secrets:    secrets,
-			parameters: []scanner.Parameter{},
-			filetype:   &scanner.FileType{},
-			errors:     []scanner.ErrorMatched{},
-			infos:      []scanner.InfoMatched{},
-			want:       `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"secrets":[{"name":"mysecret","match":"it's a random day for my secret regex to be found"}]}}`, //nolint:lll
+			name: "test_only_secrets",
+			r:    resp,
+			matches: &scanner.Results{
+				Secrets: secrets,
+			},
+			want: `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"secrets":[{"name":"mysecret","match":"it's a random day for my secret regex to be found","severity":"high","confidence":"high","fingerprint":"a22ca0cab423acf7"}]}}`, //nolint:lll
 		},
 		{
-			name:       "test_only_params",
-			r:          resp,
-			secrets:    []scanner.SecretMatched{},
-			parameters: parameters,
-			filetype:   &scanner.FileType{},
-			errors:     []scanner.ErrorMatched{},
-			infos:      []scanner.InfoMatched{},
-			want:       `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"parameters":[{"name":"id","attacks":[]}]}}`, //nolint:lll
+			name: "test_only_params",
+			r:    resp,
+			matches: &scanner.Results{
+				Endpoints: endpoints,
+			},
+			want: `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"parameters":[{"name":"id","attacks":[],"severity":"info","fingerprint":"175333752d137ec9"}]}}`, //nolint:lll
 		},
 		{
-			name:       "test_only_errors",
-			r:          resp,
-			secrets:    []scanner.SecretMatched{},
-			parameters: []scanner.Parameter{},
-			filetype:   &scanner.FileType{},
-			errors:     errors,
-			infos:      []scanner.InfoMatched{},
-			want:       `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"errors":[{"name":"MySQL error","match":"it is a MySQL error happening"}]}}`, //nolint:lll
+			name: "test_only_errors",
+			r:    resp,
+			matches: &scanner.Results{
+				Errors: errors,
+			},
+			want: `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"errors":[{"name":"MySQL error","match":"it is a MySQL error happening","severity":"medium","confidence":"medium","fingerprint":"697095c9016ed0e4"}]}}`, //nolint:lll
 		},
 		{
-			name:       "test_only_infos",
-			r:          resp,
-			secrets:    []scanner.SecretMatched{},
-			parameters: []scanner.Parameter{},
-			filetype:   &scanner.FileType{},
-			errors:     []scanner.ErrorMatched{},
-			infos:      infos,
-			want:       `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"infos":[{"name":"info1","match":"its my pleasure to inform you on this great day"}]}}`, //nolint:lll
+			name: "test_only_infos",
+			r:    resp,
+			matches: &scanner.Results{
+				Infos: infos,
+			},
+			want: `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"infos":[{"name":"info1","match":"its my pleasure to inform you on this great day","severity":"info","confidence":"medium","fingerprint":"2655ac7af3c8ce06"}]}}`, //nolint:lll
+		},
+		{
+			name: "test_only_jwts",
+			r:    resp,
+			matches: &scanner.Results{
+				JWTs: jwts,
+			},
+			want: `{"url":"http://test.com.pdf?id=5","method":"GET","status_code":200,"words":1,"lines":1,"content_type":"application/pdf","content_length":128,"matches":{"jwts":[{"match":"eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0.eyJpc3MiOiJjYXJpZGRpIn0.","source":"cookie","alg":"none","typ":"JWT","iss":"cariddi","claims":{"iss":"cariddi"},"issues":["Unsigned token (alg none)","Token without expiration"],"severity":"high","confidence":"high","fingerprint":"b4d9ec8fbeb6b165"}]}}`, //nolint:lll
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got, _ := output.GetJSONString(tt.r, tt.secrets, tt.parameters, tt.filetype, tt.errors, tt.infos); !reflect.DeepEqual(string(got), tt.want) { //nolint:lll
+			if got, _ := output.GetJSONString(tt.r, tt.matches); !reflect.DeepEqual(string(got), tt.want) {
 				t.Errorf("GetJSONString\n%v", string(got))
 				t.Errorf("want\n%v", tt.want)
 			}
@@ -0,0 +1,155 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// MarkdownWriter writes a Markdown report with a table
+// for each type of finding and host: output-cariddi/name.md.
+type MarkdownWriter struct {
+	Dir      string
+	Name     string
+	urls     int
+	findings []Finding
+}
+
+// markdownEscaper escapes the characters that could break a
+// Markdown table cell or list item (pipes and new lines) or be
+// rendered as emphasis, code spans, links or raw HTML.
+var markdownEscaper = strings.NewReplacer( // nolint: gochecknoglobals
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", "&lt;", "|", `\|`,
+	"\r\n", " ", "\n", " ", "\r", " ")
+
+// NewMarkdownWriter returns a writer of the Markdown report name.md.
+func NewMarkdownWriter(name string) *MarkdownWriter {
+	return &MarkdownWriter{Dir: CariddiOutputFolder, Name: name}
+}
+
+// Start resets the findings.
+func (w *MarkdownWriter) Start(info *ScanInfo) error {
+	w.urls = 0
+	w.findings = []Finding{}
+
+	return nil
+}
+
+// Write counts an URL or collects a finding.
+func (w *MarkdownWriter) Write(finding Finding) error {
+	if finding.Kind == FindingURL {
+		w.urls++
+		return nil
+	}
+
+	w.findings = append(w.findings, finding)
+
+	return nil
+}
+
+// Finish writes the Markdown report.
+func (w *MarkdownWriter) Finish() error {
+	return writeOutputFile(w.Dir, strings.TrimSuffix(w.Name, ".md"), "", "md",
+		[]byte(formatMarkdown(w.urls, w.findings)))
+}
+
+// FormatMarkdown returns the Markdown report of the results: a summary
+// and then, for each type of finding, a table for each host.
+func FormatMarkdown(results *scanner.Results) string {
+	return formatMarkdown(len(results.URLs), GetFindings(results))
+}
+
+// formatMarkdown returns the Markdown report of the findings.
+func formatMarkdown(urls int, findings []Finding) string {
+	var sb strings.Builder
+
+	kinds := findingKinds(findings)
+
+	sb.WriteString("# cariddi report\n\n")
+	sb.WriteString("- URLs: " + strconv.Itoa(urls) + "\n")
+	sb.WriteString("- Findings: " + strconv.Itoa(len(findings)) + "\n")
+
+	if len(findings) == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("\n| Type | Findings |\n| --- | --- |\n")
+
+	byKind := map[string][]Finding{}
+	for _, f := range findings {
+		byKind[f.Kind] = append(byKind[f.Kind], f)
+	}
+
+	for _, kind := range kinds {
+		sb.WriteString("| " + kind + " | " + strconv.Itoa(len(byKind[kind])) + " |\n")
+	}
+
+	for _, kind := range kinds {
+		sb.WriteString("\n## " + kind + " (" + strconv.Itoa(len(byKind[kind])) + ")\n")
+
+		hosts := []string{}
+		byHost := map[string][]Finding{}
+
+		for _, f := range byKind[kind] {
+			if _, ok := byHost[f.Host]; !ok {
+				hosts = append(hosts, f.Host)
+			}
+
+			byHost[f.Host] = append(byHost[f.Host], f)
+		}
+
+		sort.Strings(hosts)
+
+		for _, host := range hosts {
+			title := host
+			if title == "" {
+				title = "Unknown host"
+			}
+
+			sb.WriteString("\n### " + markdownEscaper.Replace(title) + "\n\n")
+			sb.WriteString("| Name | Severity | Confidence | URL | Match | Fingerprint |\n")
+			sb.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+
+			for _, f := range byHost[host] {
+				sb.WriteString("| " + strings.Join([]string{
+					markdownEscaper.Replace(f.Name),
+					f.Severity,
+					f.Confidence,
+					markdownEscaper.Replace(f.URL),
+					markdownEscaper.Replace(f.Snippet),
+					f.Fingerprint,
+				}, " | ") + " |\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
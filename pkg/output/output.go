@@ -28,11 +28,10 @@ package output
 
 import (
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
-	fileUtils "github.com/edoardottt/cariddi/internal/file"
-	"github.com/edoardottt/cariddi/pkg/input"
+	sliceUtils "github.com/edoardottt/cariddi/internal/slice"
 	"github.com/edoardottt/cariddi/pkg/scanner"
 )
 
@@ -47,169 +46,215 @@ func PrintSimpleOutput(out []string) {
 	}
 }
 
-// TxtOutput it's the wrapper around all the txt things.
-// Actually it manages everything related to TXT output.
-func TxtOutput(flags input.Input, finalResults []string, finalSecret []scanner.SecretMatched,
-	finalEndpoints []scanner.EndpointMatched, finalExtensions []scanner.FileTypeMatched,
-	finalErrors []scanner.ErrorMatched, finalInfos []scanner.InfoMatched) {
-	exists, err := fileUtils.ElementExists(CariddiOutputFolder)
-	if err != nil {
-		fmt.Println("Error while creating the output directory.")
-		os.Exit(1)
+// FormatJWT returns a human readable description of a JWT found.
+func FormatJWT(elem scanner.JWTMatched) string {
+	fields := []string{"alg=" + elem.JWT.Algorithm}
+
+	if elem.JWT.Issuer != "" {
+		fields = append(fields, "iss="+elem.JWT.Issuer)
 	}
 
-	if !exists {
-		fileUtils.CreateOutputFolder()
+	if elem.JWT.Subject != "" {
+		fields = append(fields, "sub="+elem.JWT.Subject)
 	}
 
-	ResultFilename := fileUtils.CreateOutputFile(flags.TXTout, "results", "txt")
-	for _, elem := range finalResults {
-		AppendOutputToTxt(elem, ResultFilename)
+	if elem.JWT.ExpiresAt != 0 {
+		fields = append(fields, "exp="+time.Unix(elem.JWT.ExpiresAt, 0).UTC().Format(time.RFC3339))
 	}
 
-	// if secrets flag enabled save also secrets
-	if flags.Secrets {
-		SecretFilename := fileUtils.CreateOutputFile(flags.TXTout, "secrets", "txt")
-		for _, elem := range finalSecret {
-			AppendOutputToTxt(elem.Secret.Name+" - "+elem.Match+" in "+elem.URL, SecretFilename)
-		}
+	result := strings.Join(fields, " ")
+
+	if len(elem.JWT.Issues) != 0 {
+		result += " (" + strings.Join(elem.JWT.Issues, ", ") + ")"
 	}
 
-	// if endpoints flag enabled save also endpoints
-	if flags.Endpoints {
-		EndpointFilename := fileUtils.CreateOutputFile(flags.TXTout, "endpoints", "txt")
+	return result + " - " + elem.Match + " (" + elem.Source + ")"
+}
 
-		for _, elem := range finalEndpoints {
-			for _, parameter := range elem.Parameters {
-				finalString := "" + parameter.Parameter
-				if len(parameter.Attacks) != 0 {
-					finalString += " -"
-					for _, attack := range parameter.Attacks {
-						finalString += " " + attack
-					}
-				}
+// FormatHeader returns a human readable description of a header issue.
+func FormatHeader(elem scanner.HeaderMatched) string {
+	if elem.Match == "" {
+		return elem.Header.Name
+	}
+
+	return elem.Header.Name + " - " + elem.Header.HTTPHeader + ": " + elem.Match
+}
 
-				AppendOutputToTxt(finalString+" in "+elem.URL, EndpointFilename)
-			}
+// FormatCookie returns a human readable description of a cookie issue.
+func FormatCookie(elem scanner.CookieMatched) string {
+	issues := []string{}
+
+	for _, issue := range elem.Cookie.Issues {
+		switch {
+		case issue == scanner.CookieJWT && len(elem.Cookie.JWTIssues) != 0:
+			issue += " (" + strings.Join(elem.Cookie.JWTIssues, ", ") + ")"
+		case issue == scanner.CookieSerialized && elem.Cookie.Format != "":
+			issue += " (" + elem.Cookie.Format + ")"
 		}
+
+		issues = append(issues, issue)
 	}
 
-	// if extensions flag enabled save also secrets
-	if 1 <= flags.Extensions && flags.Extensions <= 7 {
-		ExtensionsFilename := fileUtils.CreateOutputFile(flags.TXTout, "extensions", "txt")
-		for _, elem := range finalExtensions {
-			AppendOutputToTxt(elem.Filetype.Extension+" in "+elem.URL, ExtensionsFilename)
-		}
+	result := elem.Cookie.Name + " - " + strings.Join(issues, ", ")
+
+	if elem.Cookie.Decoded != "" {
+		result += " (decoded: " + elem.Cookie.Decoded + ")"
 	}
 
-	// if errors flag enabled save also errors
-	if flags.Errors {
-		ErrorsFilename := fileUtils.CreateOutputFile(flags.TXTout, "errors", "txt")
-		for _, elem := range finalErrors {
-			AppendOutputToTxt(elem.Error.ErrorName+" - "+elem.Match+" in "+elem.URL, ErrorsFilename)
-		}
+	return result
+}
+
+// FormatCORS returns a human readable description of a CORS misconfiguration.
+func FormatCORS(elem scanner.CORSMatched) string {
+	return elem.CORS.Name + " (" + elem.CORS.Severity + ") - Origin: " + elem.CORS.Origin + " -> " + elem.Match
+}
+
+// FormatTechnology returns a human readable description of a technology.
+func FormatTechnology(elem scanner.TechnologyMatched) string {
+	result := elem.Technology.Name
+
+	if elem.Technology.Version != "" {
+		result += " " + elem.Technology.Version
 	}
 
-	// if info flag enabled save also infos
-	if flags.Info {
-		InfosFilename := fileUtils.CreateOutputFile(flags.TXTout, "info", "txt")
-		for _, elem := range finalInfos {
-			AppendOutputToTxt(elem.Info.Name+" - "+elem.Match+" in "+elem.URL, InfosFilename)
-		}
+	if len(elem.Technology.Categories) != 0 {
+		result += " (" + strings.Join(elem.Technology.Categories, ", ") + ")"
 	}
+
+	return result
 }
 
-// HtmlOutput it's the wrapper around all the html things.
-// Actually it manages everything related to HTML output.
-func HTMLOutput(flags input.Input, resultFilename string, finalResults []string, finalSecret []scanner.SecretMatched,
-	finalEndpoints []scanner.EndpointMatched, finalExtensions []scanner.FileTypeMatched,
-	finalErrors []scanner.ErrorMatched, finalInfos []scanner.InfoMatched) {
-	exists, err := fileUtils.ElementExists(CariddiOutputFolder)
+// FormatJSLibrary returns a human readable description of a
+// vulnerable JavaScript library.
+func FormatJSLibrary(elem scanner.JSLibraryMatched) string {
+	ids := []string{}
+	for _, vulnerability := range elem.JSLibrary.Vulnerabilities {
+		ids = append(ids, vulnerability.IDs()...)
+	}
 
-	if err != nil {
-		fmt.Println("Error while creating the output directory.")
-		os.Exit(1)
+	return elem.JSLibrary.Name + " " + elem.JSLibrary.Version + " (" + elem.JSLibrary.Severity + ") - " +
+		strings.Join(sliceUtils.RemoveDuplicateValues(ids), ", ")
+}
+
+// FormatHost returns a human readable description of a host found.
+func FormatHost(elem scanner.HostMatched) string {
+	return elem.Host.Name + " (" + elem.Host.Scope + ", " + elem.Host.Source + ")"
+}
+
+// FormatBucket returns a human readable description of a bucket found.
+func FormatBucket(elem scanner.BucketMatched) string {
+	result := elem.Bucket.Provider + " - " + elem.Bucket.Name
+
+	if elem.Bucket.Region != "" {
+		result += " (" + elem.Bucket.Region + ")"
 	}
 
-	if !exists {
-		fileUtils.CreateOutputFolder()
+	if elem.Bucket.Status != "" {
+		result += " [" + elem.Bucket.Status + "]"
 	}
 
-	HeaderHTML("Results found", resultFilename)
+	return result
+}
 
-	for _, elem := range finalResults {
-		AppendOutputToHTML(elem, "", resultFilename, true)
+// FormatAPI returns a human readable description of an API endpoint found.
+func FormatAPI(elem scanner.APIMatched) string {
+	result := elem.API.Method + " " + elem.API.Path + " (" + elem.API.Source + ")"
+
+	if elem.API.Operation != "" {
+		result += " - " + elem.API.Operation
 	}
 
-	FooterHTML(resultFilename)
+	if len(elem.API.Parameters) != 0 {
+		result += " - params: " + strings.Join(elem.API.Parameters, ", ")
+	}
 
-	// if secrets flag enabled save also secrets
-	if flags.Secrets {
-		HeaderHTML("Secrets found", resultFilename)
+	return result
+}
 
-		for _, elem := range finalSecret {
-			AppendOutputToHTML(elem.Secret.Name+" - "+elem.Match+" in "+elem.URL, "", resultFilename, false)
-		}
+// FormatParameter returns a human readable description of a juicy parameter found.
+func FormatParameter(parameter scanner.Parameter) string {
+	result := parameter.Parameter
 
-		FooterHTML(resultFilename)
+	if len(parameter.Attacks) != 0 {
+		result += " - " + strings.Join(parameter.Attacks, " ")
 	}
 
-	// if endpoints flag enabled save also endpoints
-	if flags.Endpoints {
-		HeaderHTML("Endpoints found", resultFilename)
+	details := []string{}
+	if parameter.ValueType != "" {
+		details = append(details, parameter.ValueType)
+	}
 
-		for _, elem := range finalEndpoints {
-			for _, parameter := range elem.Parameters {
-				finalString := "" + parameter.Parameter
-				if len(parameter.Attacks) != 0 {
-					finalString += " -"
-					for _, attack := range parameter.Attacks {
-						finalString += " " + attack
-					}
-				}
+	if parameter.OffSite {
+		details = append(details, "off-site")
+	}
 
-				AppendOutputToHTML(finalString+" in "+elem.URL, "", resultFilename, false)
-			}
-		}
+	if parameter.Reflected {
+		details = append(details, "reflected")
+	}
 
-		FooterHTML(resultFilename)
+	if len(details) != 0 {
+		result += " (" + strings.Join(details, ", ") + ")"
 	}
 
-	// if extensions flag enabled save also extensions
-	if 1 <= flags.Extensions && flags.Extensions <= 7 {
-		HeaderHTML("Extensions found", resultFilename)
+	if parameter.Confidence != "" {
+		result += " [confidence: " + parameter.Confidence + "]"
+	}
 
-		for _, elem := range finalExtensions {
-			AppendOutputToHTML(elem.Filetype.Extension+" in "+elem.URL, "", resultFilename, false)
-		}
+	return result
+}
+
+// FormatPath returns a human readable description of a juicy path found.
+func FormatPath(path scanner.JuicyPath) string {
+	return path.Name + " - " + path.Category + " (" + path.Severity + ")"
+}
 
-		FooterHTML(resultFilename)
+// FormatReflection returns a human readable description of a reflection point found.
+func FormatReflection(elem scanner.ReflectionMatched) string {
+	result := elem.Reflection.Parameter + " - " + elem.Reflection.Context + " context"
+
+	if len(elem.Reflection.Characters) != 0 {
+		result += " [unfiltered: " + strings.Join(elem.Reflection.Characters, " ") + "]"
 	}
 
-	// if errors flag enabled save also errors
-	if flags.Errors {
-		HeaderHTML("Errors found", resultFilename)
+	return result + " (" + elem.Reflection.Severity + ")"
+}
 
-		for _, elem := range finalErrors {
-			AppendOutputToHTML(elem.Error.ErrorName+" - "+elem.Match+" in "+elem.URL, "", resultFilename, false)
-		}
+// FormatRedirect returns a human readable description of an open redirect found.
+func FormatRedirect(elem scanner.RedirectMatched) string {
+	return elem.Redirect.Parameter + " - " + elem.Redirect.Method + " -> " + elem.Redirect.Location +
+		" (" + elem.Redirect.Severity + ")"
+}
+
+// FormatExposure returns a human readable description of an exposed file found.
+func FormatExposure(elem scanner.ExposureMatched) string {
+	result := elem.Exposure.Name + " (" + elem.Exposure.Category + ", " + elem.Exposure.Severity + ")"
+
+	if elem.Exposure.Evidence != "" {
+		result += " - " + elem.Exposure.Evidence
+	}
+
+	return result
+}
 
-		FooterHTML(resultFilename)
+// FormatExtension returns a human readable description of a file type found.
+func FormatExtension(elem scanner.FileTypeMatched) string {
+	if elem.Signal == "" {
+		return elem.Filetype.Extension
 	}
 
-	// if info flag enabled save also infos
-	if flags.Info {
-		HeaderHTML("Useful informations found", resultFilename)
+	return elem.Filetype.Extension + " (" + elem.Signal + ")"
+}
 
-		for _, elem := range finalInfos {
-			// Escape HTML comment to be shown on the result page
-			AppendOutputToHTML(elem.Info.Name+" - "+
-				strings.ReplaceAll(strings.ReplaceAll(elem.Match, "<", "&lt;"), ">", "&gt;")+
-				" in "+elem.URL, "", resultFilename, false)
-		}
+// FormatRating returns the severity and the confidence of a finding.
+func FormatRating(finding scanner.Finding) string {
+	return FormatLevels(finding.Severity(), finding.Confidence())
+}
 
-		FooterHTML(resultFilename)
+// FormatLevels returns a severity and a confidence in a human readable way.
+func FormatLevels(severity, confidence string) string {
+	if confidence == "" {
+		return " [" + severity + "]"
 	}
 
-	BannerFooterHTML(resultFilename)
+	return " [" + severity + ", " + confidence + " confidence]"
 }
@@ -0,0 +1,152 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edoardottt/cariddi/pkg/input"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+// ReportSchemaVersion is the version of the JSON report schema.
+// It changes every time a field is renamed, moved or removed.
+const ReportSchemaVersion = "1.0"
+
+// Report is the JSON document describing a whole scan: its metadata,
+// all the URLs crawled and all the deduplicated findings.
+type Report struct {
+	SchemaVersion string          `json:"schema_version"`
+	Scan          ReportScan      `json:"scan"`
+	Stats         ReportStats     `json:"stats"`
+	URLs          []string        `json:"urls"`
+	Findings      *MatcherResults `json:"findings"`
+}
+
+// ReportScan contains the metadata of a scan.
+type ReportScan struct {
+	Tool      string      `json:"tool"`
+	Version   string      `json:"version"`
+	Targets   []string    `json:"targets"`
+	Options   input.Input `json:"options"`
+	StartTime time.Time   `json:"start_time"`
+	EndTime   time.Time   `json:"end_time"`
+}
+
+// ReportStats contains the numbers of a scan. The findings are
+// counted by type (secret, parameter, filetype...) and by severity.
+type ReportStats struct {
+	Duration   string         `json:"duration"`
+	Targets    int            `json:"targets"`
+	URLs       int            `json:"urls"`
+	Findings   int            `json:"findings"`
+	Types      map[string]int `json:"types"`
+	Severities map[string]int `json:"severities"`
+}
+
+// GetReport returns the JSON report of a scan.
+func GetReport(flags input.Input, targets []string, start, end time.Time, results *scanner.Results) *Report {
+	urls := results.URLs
+	if urls == nil {
+		urls = []string{}
+	}
+
+	if targets == nil {
+		targets = []string{}
+	}
+
+	stats := ReportStats{
+		Duration:   end.Sub(start).Round(time.Millisecond).String(),
+		Targets:    len(targets),
+		URLs:       len(urls),
+		Types:      map[string]int{},
+		Severities: map[string]int{},
+	}
+
+	for _, finding := range GetFindings(results) {
+		stats.Findings++
+		stats.Types[finding.Kind]++
+		stats.Severities[finding.Severity]++
+	}
+
+	return &Report{
+		SchemaVersion: ReportSchemaVersion,
+		Scan: ReportScan{
+			Tool:      "cariddi",
+			Version:   version,
+			Targets:   targets,
+			Options:   flags,
+			StartTime: start,
+			EndTime:   end,
+		},
+		Stats:    stats,
+		URLs:     urls,
+		Findings: newMatcherResults(results, "", true),
+	}
+}
+
+// ReportWriter writes the JSON report of a scan: output-cariddi/name.json.
+type ReportWriter struct {
+	Dir     string
+	Name    string
+	info    *ScanInfo
+	results *scanner.Results
+}
+
+// NewReportWriter returns a writer of the JSON report name.json.
+func NewReportWriter(name string) *ReportWriter {
+	return &ReportWriter{Dir: CariddiOutputFolder, Name: name}
+}
+
+// Start keeps the scan metadata and resets the results.
+func (w *ReportWriter) Start(info *ScanInfo) error {
+	w.info = info
+	w.results = &scanner.Results{}
+
+	return nil
+}
+
+// Write collects the result of a finding.
+func (w *ReportWriter) Write(finding Finding) error {
+	appendResult(w.results, finding)
+	return nil
+}
+
+// Finish writes the JSON report.
+func (w *ReportWriter) Finish() error {
+	report := GetReport(w.info.Options, w.info.Targets, w.info.StartTime, w.info.EndTime, w.results)
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while writing the JSON report: %w", err)
+	}
+
+	return writeOutputFile(w.Dir, strings.TrimSuffix(w.Name, ".json"), "", "json", append(data, '\n'))
+}
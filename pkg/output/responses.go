@@ -164,8 +164,10 @@ func WriteHTTPResponse(inputURL *url.URL, response []byte) error {
 }
 
 // StoreHTTPResponse stores an HTTP response in a file.
+// If replacer is not nil it is applied to the formatted
+// response before writing it (e.g. to redact secrets).
 // If it fails returns an error.
-func StoreHTTPResponse(r *colly.Response) error {
+func StoreHTTPResponse(r *colly.Response, replacer *strings.Replacer) error {
 	fileUtils.CreateHostOutputFolder(r.Request.URL.Host)
 
 	err := UpdateIndex(r)
@@ -178,6 +180,10 @@ func StoreHTTPResponse(r *colly.Response) error {
 		log.Println(err)
 	}
 
+	if replacer != nil {
+		response = []byte(replacer.Replace(string(response)))
+	}
+
 	err = WriteHTTPResponse(r.Request.URL, response)
 	if err != nil {
 		log.Println(err)
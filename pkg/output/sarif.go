@@ -0,0 +1,316 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	// sarifFingerprint is the name of the cariddi fingerprints
+	// in the partialFingerprints of the results.
+	sarifFingerprint = "cariddiFingerprint/v1"
+	// sarifColumnKind is the unit of the regions (the matches
+	// offsets are counted in characters, not in UTF-16 units).
+	sarifColumnKind = "unicodeCodePoints"
+)
+
+// SARIF levels.
+const (
+	SARIFError   = "error"
+	SARIFWarning = "warning"
+	SARIFNote    = "note"
+)
+
+// nonSlugChars matches the characters replaced in the rule ids.
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`) // nolint: gochecknoglobals
+
+// SARIFLog struct.
+// It's the root object of a SARIF file.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun struct.
+// It contains the tool and the results of a scan.
+// ColumnKind = the unit of the columns and of the offsets of the regions.
+type SARIFRun struct {
+	Tool       SARIFTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []SARIFResult `json:"results"`
+}
+
+// SARIFTool struct.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver struct.
+// It describes cariddi and the rules matched.
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFMessage struct.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFRule struct.
+// A rule is a kind of finding (e.g. secret/aws-access-key).
+type SARIFRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     SARIFMessage       `json:"shortDescription"`
+	FullDescription      SARIFMessage       `json:"fullDescription"`
+	DefaultConfiguration SARIFConfiguration `json:"defaultConfiguration"`
+	Properties           SARIFRuleProps     `json:"properties"`
+}
+
+// SARIFConfiguration struct.
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFRuleProps struct.
+// SecuritySeverity = the numeric score used by the code scanning dashboards.
+type SARIFRuleProps struct {
+	Tags             []string `json:"tags"`
+	SecuritySeverity string   `json:"security-severity"`
+}
+
+// SARIFResult struct.
+// It's a finding, with the URL as location.
+type SARIFResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             SARIFMessage      `json:"message"`
+	Locations           []SARIFLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          SARIFResultProps  `json:"properties"`
+}
+
+// SARIFLocation struct.
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation struct.
+// Region = the position of the match in the response body (if known).
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation struct.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion struct.
+// Lines and columns are 1-based, the offsets are 0-based.
+type SARIFRegion struct {
+	StartLine   int          `json:"startLine"`
+	StartColumn int          `json:"startColumn"`
+	CharOffset  int          `json:"charOffset"`
+	CharLength  int          `json:"charLength"`
+	Snippet     SARIFContent `json:"snippet"`
+}
+
+// SARIFContent struct.
+type SARIFContent struct {
+	Text string `json:"text"`
+}
+
+// SARIFResultProps struct.
+// Snippet = the evidence of the finding (if any), also for
+// the findings without a region (e.g. the headers).
+type SARIFResultProps struct {
+	Severity   string `json:"severity"`
+	Confidence string `json:"confidence"`
+	Snippet    string `json:"snippet,omitempty"`
+}
+
+// SARIFLevel returns the SARIF level of a severity.
+func SARIFLevel(severity string) string {
+	switch severity {
+	case scanner.SeverityCritical, scanner.SeverityHigh:
+		return SARIFError
+	case scanner.SeverityMedium:
+		return SARIFWarning
+	}
+
+	return SARIFNote
+}
+
+// SARIFSecuritySeverity returns the numeric (CVSS like) score
+// of a severity, used by the code scanning dashboards.
+func SARIFSecuritySeverity(severity string) string {
+	switch severity {
+	case scanner.SeverityCritical:
+		return "9.5"
+	case scanner.SeverityHigh:
+		return "8.0"
+	case scanner.SeverityMedium:
+		return "5.5"
+	case scanner.SeverityLow:
+		return "3.0"
+	}
+
+	return "0.0"
+}
+
+// SARIFRuleID returns the id of the rule of a finding (e.g. secret/aws-access-key).
+func SARIFRuleID(kind, name string) string {
+	return kind + "/" + strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// GetSARIF returns the SARIF log of the results.
+func GetSARIF(results *scanner.Results) *SARIFLog {
+	return sarifLog(GetFindings(results))
+}
+
+// sarifLog returns the SARIF log of the findings (the URLs are skipped).
+// A rule is defined for each rule matched, with the highest severity found.
+func sarifLog(findings []Finding) *SARIFLog {
+	driver := SARIFDriver{
+		Name:           "cariddi",
+		Version:        version,
+		InformationURI: "https://github.com/edoardottt/cariddi",
+		Rules:          []SARIFRule{},
+	}
+	sarifResults := []SARIFResult{}
+	ruleIndex := map[string]int{}
+	ruleSeverity := map[string]string{}
+
+	for _, finding := range findings {
+		if finding.Kind == FindingURL {
+			continue
+		}
+
+		id := SARIFRuleID(finding.Kind, finding.Name)
+
+		index, ok := ruleIndex[id]
+		if !ok {
+			index = len(driver.Rules)
+			ruleIndex[id] = index
+			driver.Rules = append(driver.Rules, SARIFRule{
+				ID:                   id,
+				Name:                 finding.Name,
+				ShortDescription:     SARIFMessage{finding.Kind + ": " + finding.Name},
+				FullDescription:      SARIFMessage{finding.Description},
+				DefaultConfiguration: SARIFConfiguration{SARIFLevel(finding.Severity)},
+				Properties: SARIFRuleProps{
+					Tags:             []string{"security", finding.Kind},
+					SecuritySeverity: SARIFSecuritySeverity(finding.Severity),
+				},
+			})
+		}
+
+		if scanner.SeverityRank(finding.Severity) > scanner.SeverityRank(ruleSeverity[id]) {
+			ruleSeverity[id] = finding.Severity
+			rule := &driver.Rules[index]
+			rule.DefaultConfiguration.Level = SARIFLevel(finding.Severity)
+			rule.Properties.SecuritySeverity = SARIFSecuritySeverity(finding.Severity)
+		}
+
+		location := SARIFPhysicalLocation{ArtifactLocation: SARIFArtifactLocation{finding.URL}}
+		if finding.Region != nil {
+			location.Region = &SARIFRegion{
+				StartLine:   finding.Region.Line,
+				StartColumn: finding.Region.Column,
+				CharOffset:  finding.Region.Offset,
+				CharLength:  finding.Region.Length,
+				Snippet:     SARIFContent{finding.Snippet},
+			}
+		}
+
+		sarifResults = append(sarifResults, SARIFResult{
+			RuleID:              id,
+			RuleIndex:           index,
+			Level:               SARIFLevel(finding.Severity),
+			Message:             SARIFMessage{finding.Message},
+			Locations:           []SARIFLocation{{location}},
+			PartialFingerprints: map[string]string{sarifFingerprint: finding.Fingerprint},
+			Properties:          SARIFResultProps{finding.Severity, finding.Confidence, finding.Snippet},
+		})
+	}
+
+	return &SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []SARIFRun{{Tool: SARIFTool{driver}, ColumnKind: sarifColumnKind, Results: sarifResults}},
+	}
+}
+
+// SARIFWriter writes all the findings into a SARIF (2.1.0)
+// file: output-cariddi/name.sarif.
+type SARIFWriter struct {
+	Dir      string
+	Name     string
+	findings []Finding
+}
+
+// NewSARIFWriter returns a writer of the SARIF file name.sarif.
+func NewSARIFWriter(name string) *SARIFWriter {
+	return &SARIFWriter{Dir: CariddiOutputFolder, Name: name}
+}
+
+// Start resets the findings.
+func (w *SARIFWriter) Start(info *ScanInfo) error {
+	w.findings = []Finding{}
+	return nil
+}
+
+// Write collects a finding.
+func (w *SARIFWriter) Write(finding Finding) error {
+	w.findings = append(w.findings, finding)
+	return nil
+}
+
+// Finish writes the SARIF file.
+func (w *SARIFWriter) Finish() error {
+	data, err := json.MarshalIndent(sarifLog(w.findings), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while writing the SARIF output: %w", err)
+	}
+
+	return writeOutputFile(w.Dir, strings.TrimSuffix(w.Name, ".sarif"), "", "sarif", append(data, '\n'))
+}
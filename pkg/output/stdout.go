@@ -0,0 +1,110 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+	"github.com/fatih/color"
+	"github.com/gocolly/colly"
+)
+
+// StdoutWriter prints the URLs while crawling and then all the
+// findings (only the URLs in plain mode).
+// The technologies are printed host by host.
+type StdoutWriter struct {
+	Out          io.Writer
+	Plain        bool
+	technologies []Finding
+}
+
+// NewStdoutWriter returns a writer printing into the standard output.
+func NewStdoutWriter(plain bool) *StdoutWriter {
+	return &StdoutWriter{Out: os.Stdout, Plain: plain}
+}
+
+// WriteResponse prints the URL of a response.
+func (w *StdoutWriter) WriteResponse(r *colly.Response, matches *scanner.Results) error {
+	_, err := fmt.Fprintln(w.Out, r.Request.URL)
+	return err
+}
+
+// Start resets the technologies.
+func (w *StdoutWriter) Start(info *ScanInfo) error {
+	w.technologies = []Finding{}
+	return nil
+}
+
+// Write prints a finding (the URLs are already printed).
+func (w *StdoutWriter) Write(finding Finding) error {
+	if w.Plain || finding.Kind == FindingURL {
+		return nil
+	}
+
+	if finding.Kind == FindingTechnology {
+		w.technologies = append(w.technologies, finding)
+		return nil
+	}
+
+	if err := w.printTechnologies(); err != nil {
+		return err
+	}
+
+	return w.print(finding)
+}
+
+// Finish prints the technologies left.
+func (w *StdoutWriter) Finish() error {
+	return w.printTechnologies()
+}
+
+// printTechnologies prints the technologies collected, host by host.
+func (w *StdoutWriter) printTechnologies() error {
+	for _, finding := range groupTechnologies(w.technologies) {
+		if err := w.print(finding); err != nil {
+			return err
+		}
+	}
+
+	w.technologies = []Finding{}
+
+	return nil
+}
+
+// print prints a finding as "[ label ] detail", with a green label.
+func (w *StdoutWriter) print(finding Finding) error {
+	if _, err := color.New(color.FgGreen).Fprintf(w.Out, "[ %s ] ", finding.Label); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(w.Out, finding.Detail)
+
+	return err
+}
@@ -27,23 +27,50 @@ along with this program.  If not, see http://www.gnu.org/licenses/.
 package output
 
 import (
-	"log"
-	"os"
-
-	fileUtils "github.com/edoardottt/cariddi/internal/file"
+	"strings"
 )
 
-// AppendOutputToTxt opens the output file and append
-// the string taken as input.
-func AppendOutputToTxt(output string, filename string) {
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, fileUtils.Permission0644)
-	if err != nil {
-		log.Println(err)
-	}
+// TxtWriter writes the results into a TXT file for each
+// section: output-cariddi/name.section.txt.
+// The sections enabled are written even if empty.
+type TxtWriter struct {
+	Dir      string
+	Name     string
+	Sections []string
+	findings []Finding
+}
+
+// NewTxtWriter returns a writer of the TXT files name.section.txt.
+func NewTxtWriter(name string, sections []string) *TxtWriter {
+	return &TxtWriter{Dir: CariddiOutputFolder, Name: name, Sections: sections}
+}
+
+// Start resets the findings.
+func (w *TxtWriter) Start(info *ScanInfo) error {
+	w.findings = []Finding{}
+	return nil
+}
+
+// Write collects a finding.
+func (w *TxtWriter) Write(finding Finding) error {
+	w.findings = append(w.findings, finding)
+	return nil
+}
+
+// Finish writes the TXT files.
+func (w *TxtWriter) Finish() error {
+	sections, findings := sectionFindings(w.Sections, w.findings)
+
+	for _, s := range sections {
+		var sb strings.Builder
+		for _, f := range findings[s.name] {
+			sb.WriteString(f.Text + "\n")
+		}
 
-	if _, err := file.WriteString(output + "\n"); err != nil {
-		log.Fatal(err)
+		if err := writeOutputFile(w.Dir, w.Name, s.name, "txt", []byte(sb.String())); err != nil {
+			return err
+		}
 	}
 
-	file.Close()
+	return nil
 }
@@ -0,0 +1,293 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	fileUtils "github.com/edoardottt/cariddi/internal/file"
+	"github.com/edoardottt/cariddi/pkg/input"
+	"github.com/edoardottt/cariddi/pkg/scanner"
+	"github.com/gocolly/colly"
+)
+
+// ScanInfo describes the scan whose results are written.
+type ScanInfo struct {
+	Targets   []string
+	Options   input.Input
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Writer writes the final results of a scan in an output format.
+// Start is called before the results, Write for each URL crawled
+// and then for each finding (type by type), Finish after the last one.
+type Writer interface {
+	Start(info *ScanInfo) error
+	Write(finding Finding) error
+	Finish() error
+}
+
+// ResponseWriter is a Writer that also writes the matches
+// of each response while crawling.
+type ResponseWriter interface {
+	Writer
+	WriteResponse(r *colly.Response, matches *scanner.Results) error
+}
+
+// Writers is the registry of the writers enabled.
+// All the writers receive the same results.
+type Writers struct {
+	writers []Writer
+}
+
+// NewWriters returns the registry of the writers enabled by the flags.
+func NewWriters(flags input.Input) *Writers {
+	w := &Writers{}
+
+	if flags.JSON {
+		w.Register(NewJSONLWriter())
+	} else {
+		w.Register(NewStdoutWriter(flags.Plain))
+	}
+
+	sections := Sections(flags)
+
+	if flags.TXTout != "" {
+		w.Register(NewTxtWriter(flags.TXTout, sections))
+	}
+
+	if flags.HTMLout != "" {
+		w.Register(NewHTMLWriter(flags.HTMLout, sections))
+	}
+
+	if flags.SARIFout != "" {
+		w.Register(NewSARIFWriter(flags.SARIFout))
+	}
+
+	if flags.JSONout != "" {
+		w.Register(NewReportWriter(flags.JSONout))
+	}
+
+	if flags.CSVout != "" {
+		w.Register(NewCSVWriter(flags.CSVout))
+	}
+
+	if flags.MDout != "" {
+		w.Register(NewMarkdownWriter(flags.MDout))
+	}
+
+	return w
+}
+
+// Register enables a writer.
+func (w *Writers) Register(writer Writer) {
+	w.writers = append(w.writers, writer)
+}
+
+// WriteResponse writes the matches of a response
+// with all the response writers enabled.
+func (w *Writers) WriteResponse(r *colly.Response, matches *scanner.Results) error {
+	if w == nil {
+		return nil
+	}
+
+	for _, writer := range w.writers {
+		if responseWriter, ok := writer.(ResponseWriter); ok {
+			if err := responseWriter.WriteResponse(r, matches); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// WriteResults writes the final results of a scan with all the writers enabled:
+// the URLs crawled first, then the findings.
+func (w *Writers) WriteResults(info *ScanInfo, results *scanner.Results) error {
+	if w == nil {
+		return nil
+	}
+
+	findings := []Finding{}
+	for _, url := range results.URLs {
+		findings = append(findings, URLFinding(url))
+	}
+
+	findings = append(findings, GetFindings(results)...)
+
+	for _, writer := range w.writers {
+		if err := writer.Start(info); err != nil {
+			return err
+		}
+
+		for _, finding := range findings {
+			if err := writer.Write(finding); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Finish(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// section is a group of findings written together in the text outputs.
+// name = the name of the TXT file (output-cariddi/target.name.txt).
+// title = the title of the HTML list.
+type section struct {
+	name  string
+	title string
+	kinds []string
+}
+
+// sections returns the sections of the text outputs, in order.
+func sections() []section {
+	return []section{
+		{"results", "Results found", []string{FindingURL}},
+		{"secrets", "Secrets found", []string{FindingSecret}},
+		{"endpoints", "Endpoints found", []string{FindingParameter, FindingPath}},
+		{"extensions", "Extensions found", []string{FindingFileType}},
+		{"errors", "Errors found", []string{FindingError}},
+		{"info", "Useful informations found", []string{FindingInfo}},
+		{"jwts", "JWTs found", []string{FindingJWT}},
+		{"headers", "Header issues found", []string{FindingHeader}},
+		{"cookies", "Cookie issues found", []string{FindingCookie}},
+		{"cors", "CORS misconfigurations found", []string{FindingCORS}},
+		{"technologies", "Technologies found", []string{FindingTechnology}},
+		{"jslibs", "Vulnerable JavaScript libraries found", []string{FindingJSLibrary}},
+		{"hosts", "Hosts found", []string{FindingHost}},
+		{"buckets", "Cloud buckets found", []string{FindingBucket}},
+		{"apis", "API endpoints found", []string{FindingAPI}},
+		{"reflections", "XSS reflection points found", []string{FindingReflection}},
+		{"redirects", "Open redirects found", []string{FindingRedirect}},
+		{"exposures", "Exposed files found", []string{FindingExposure}},
+	}
+}
+
+// Sections returns the names of the sections of the text outputs
+// enabled by the flags: they are written even if empty.
+func Sections(flags input.Input) []string {
+	enabled := map[string]bool{
+		"results":      true,
+		"secrets":      flags.Secrets,
+		"endpoints":    flags.Endpoints,
+		"extensions":   1 <= flags.Extensions && flags.Extensions <= 7,
+		"errors":       flags.Errors,
+		"info":         flags.Info,
+		"jwts":         flags.Decode > 0,
+		"headers":      flags.SecHeaders,
+		"cookies":      flags.Cookies,
+		"cors":         flags.CORS,
+		"technologies": flags.Tech,
+		"jslibs":       flags.JSLibs,
+		"hosts":        flags.Hosts,
+		"buckets":      flags.Cloud,
+		"apis":         flags.API,
+		"reflections":  flags.XSS,
+		"redirects":    flags.Redir,
+		"exposures":    flags.Backup,
+	}
+
+	result := []string{}
+
+	for _, s := range sections() {
+		if enabled[s.name] {
+			result = append(result, s.name)
+		}
+	}
+
+	return result
+}
+
+// sectionFindings groups the findings by section. The sections
+// returned are the enabled ones and the ones with findings, in order.
+// The technologies are grouped by host.
+func sectionFindings(enabled []string, findings []Finding) ([]section, map[string][]Finding) {
+	kindSection := map[string]string{}
+	for _, s := range sections() {
+		for _, kind := range s.kinds {
+			kindSection[kind] = s.name
+		}
+	}
+
+	bySection := map[string][]Finding{}
+
+	for _, f := range findings {
+		bySection[kindSection[f.Kind]] = append(bySection[kindSection[f.Kind]], f)
+	}
+
+	bySection["technologies"] = groupTechnologies(bySection["technologies"])
+
+	isEnabled := map[string]bool{}
+	for _, name := range enabled {
+		isEnabled[name] = true
+	}
+
+	result := []section{}
+
+	for _, s := range sections() {
+		if isEnabled[s.name] || len(bySection[s.name]) != 0 {
+			result = append(result, s)
+		}
+	}
+
+	return result, bySection
+}
+
+// outputPath returns the path of an output file: dir/name(.subcommand).format.
+func outputPath(dir, name, subcommand, format string) string {
+	name = fileUtils.ReplaceBadCharacterOutput(name)
+
+	if subcommand != "" {
+		return filepath.Join(dir, name+"."+subcommand+"."+format)
+	}
+
+	return filepath.Join(dir, name+"."+format)
+}
+
+// writeOutputFile writes data into the output file
+// dir/name(.subcommand).format, creating dir if needed.
+func writeOutputFile(dir, name, subcommand, format string, data []byte) error {
+	if err := os.MkdirAll(dir, fileUtils.Permission0755); err != nil {
+		return fmt.Errorf("error while creating the output directory: %w", err)
+	}
+
+	filename := outputPath(dir, name, subcommand, format)
+	if err := os.WriteFile(filename, data, fileUtils.Permission0644); err != nil {
+		return fmt.Errorf("error while writing %s: %w", filename, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+// APIEndpoint struct.
+// Source = the kind of document describing the endpoint (openapi, swagger, graphql).
+// Method = the HTTP method (query or mutation for GraphQL).
+// Path = the documented path (the field name for GraphQL).
+// URL = the URL built with example values (if any).
+// Operation = the operation id or summary.
+// Parameters = the documented parameters.
+type APIEndpoint struct {
+	Source     string   `json:"source"`
+	Method     string   `json:"method"`
+	Path       string   `json:"path"`
+	URL        string   `json:"url,omitempty"`
+	Operation  string   `json:"operation,omitempty"`
+	Parameters []string `json:"parameters,omitempty"`
+}
+
+// APIMatched struct.
+// API = APIEndpoint struct.
+// Url = url (or file) of the specification or of the GraphQL endpoint.
+type APIMatched struct {
+	API APIEndpoint
+	URL string
+}
+
+// API sources.
+const (
+	APISourceOpenAPI = "openapi"
+	APISourceSwagger = "swagger"
+	APISourceGraphQL = "graphql"
+)
+
+// GraphQL operation types.
+const (
+	GraphQLQuery    = "query"
+	GraphQLMutation = "mutation"
+)
+
+// GetGraphQLIntrospectionQuery returns the introspection query
+// used to enumerate the queries and the mutations of a GraphQL endpoint.
+func GetGraphQLIntrospectionQuery() string {
+	return `query IntrospectionQuery { __schema { queryType { name } mutationType { name } ` +
+		`types { name fields { name args { name } } } } }`
+}
+
+// RemoveDuplicateAPIs removes duplicates from API endpoints found.
+func RemoveDuplicateAPIs(input []APIMatched) []APIMatched {
+	keys := make(map[string]bool)
+	list := []APIMatched{}
+
+	for _, entry := range input {
+		key := entry.URL + entry.API.Method + entry.API.Path
+		if _, value := keys[key]; !value {
+			keys[key] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// Severity returns the severity of the API endpoint found.
+func (a APIMatched) Severity() string {
+	return SeverityInfo
+}
+
+// Confidence returns the confidence of the API endpoint
+// found (it's documented by the specification).
+func (a APIMatched) Confidence() string {
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the API endpoint found.
+func (a APIMatched) Fingerprint() string {
+	return FindingFingerprint("api:"+a.API.Source, a.URL, a.API.Method+" "+a.API.Path)
+}
@@ -0,0 +1,48 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import "strings"
+
+// ArchiveEntry struct.
+// Path = the location of the file (archive-url!/inner/path).
+// Size = the (uncompressed) size of the file.
+// Depth = the number of archives the file is nested in.
+// Content = the content of the file (nil if over the size limit).
+type ArchiveEntry struct {
+	Path    string
+	Size    int64
+	Depth   int
+	Content []byte
+}
+
+const ArchiveSeparator = "!/"
+
+// ArchivePath returns the location of a file inside an archive.
+func ArchivePath(archive, name string) string {
+	return archive + ArchiveSeparator + strings.TrimPrefix(name, "/")
+}
@@ -0,0 +1,160 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Baseline statuses.
+const (
+	BaselineAccepted      = "accepted"
+	BaselineFalsePositive = "false-positive"
+)
+
+// BaselineDateLayout is the layout of the baseline expiry dates.
+const BaselineDateLayout = "2006-01-02"
+
+// ErrBaselineEntry is returned when an entry of the baseline is not valid.
+var ErrBaselineEntry = errors.New("invalid baseline entry")
+
+// BaselineEntry struct.
+// Fingerprint = the fingerprint of the finding.
+// Finding = what the finding is (kind and name).
+// URL = url in which the finding was found.
+// Status = accepted or false-positive.
+// Reason = why the finding was triaged this way.
+// Expires = the date (YYYY-MM-DD) after which the finding is reported again.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Finding     string `json:"finding,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason,omitempty"`
+	Expires     string `json:"expires,omitempty"`
+}
+
+// Baseline struct.
+// It's the list of the already triaged findings,
+// they are suppressed from the results.
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries"`
+	index   map[string]BaselineEntry
+}
+
+// NewBaseline returns a baseline made of the entries given.
+func NewBaseline(entries []BaselineEntry) *Baseline {
+	baseline := &Baseline{Entries: entries, index: map[string]BaselineEntry{}}
+
+	for _, entry := range entries {
+		baseline.index[entry.Fingerprint] = entry
+	}
+
+	return baseline
+}
+
+// ParseBaseline parses and validates a baseline file.
+func ParseBaseline(data []byte) (*Baseline, error) {
+	baseline := &Baseline{}
+	if err := json.Unmarshal(data, baseline); err != nil {
+		return nil, err
+	}
+
+	for i, entry := range baseline.Entries {
+		if err := entry.Validate(); err != nil {
+			return nil, fmt.Errorf("%w (entry %d)", err, i+1)
+		}
+	}
+
+	return NewBaseline(baseline.Entries), nil
+}
+
+// Validate checks the fingerprint, the status and the expiry date of the entry.
+func (e BaselineEntry) Validate() error {
+	if e.Fingerprint == "" {
+		return fmt.Errorf("%w: missing fingerprint", ErrBaselineEntry)
+	}
+
+	if e.Status != BaselineAccepted && e.Status != BaselineFalsePositive {
+		return fmt.Errorf("%w: status must be %s or %s", ErrBaselineEntry, BaselineAccepted, BaselineFalsePositive)
+	}
+
+	if e.Expires != "" {
+		if _, err := time.Parse(BaselineDateLayout, e.Expires); err != nil {
+			return fmt.Errorf("%w: expiry date must be YYYY-MM-DD", ErrBaselineEntry)
+		}
+	}
+
+	return nil
+}
+
+// Expired checks if the entry is expired. An entry without expiry
+// never expires, otherwise it's valid until the end of the expiry day.
+func (e BaselineEntry) Expired(now time.Time) bool {
+	if e.Expires == "" {
+		return false
+	}
+
+	expires, err := time.ParseInLocation(BaselineDateLayout, e.Expires, now.Location())
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(expires.AddDate(0, 0, 1))
+}
+
+// Suppresses checks if the finding with the fingerprint
+// given is in the baseline and not expired.
+func (b *Baseline) Suppresses(fingerprint string, now time.Time) bool {
+	if b == nil {
+		return false
+	}
+
+	entry, ok := b.index[fingerprint]
+
+	return ok && !entry.Expired(now)
+}
+
+// SuppressFindings returns the findings that are not in the baseline.
+func SuppressFindings[T Fingerprinted](findings []T, baseline *Baseline, now time.Time) []T {
+	if baseline == nil || findings == nil {
+		return findings
+	}
+
+	result := []T{}
+
+	for _, finding := range findings {
+		if !baseline.Suppresses(finding.Fingerprint(), now) {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
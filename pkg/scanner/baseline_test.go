@@ -0,0 +1,141 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func TestParseBaseline(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{
+			name:  "valid baseline",
+			input: `{"entries":[{"fingerprint":"a1","status":"accepted","expires":"2026-12-31"},{"fingerprint":"b2","status":"false-positive"}]}`, //nolint:lll
+		},
+		{
+			name:    "missing fingerprint",
+			input:   `{"entries":[{"status":"accepted"}]}`,
+			wantErr: scanner.ErrBaselineEntry,
+		},
+		{
+			name:    "unknown status",
+			input:   `{"entries":[{"fingerprint":"a1","status":"ignored"}]}`,
+			wantErr: scanner.ErrBaselineEntry,
+		},
+		{
+			name:    "bad expiry date",
+			input:   `{"entries":[{"fingerprint":"a1","status":"accepted","expires":"31/12/2026"}]}`,
+			wantErr: scanner.ErrBaselineEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := scanner.ParseBaseline([]byte(tt.input)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseBaseline\n%v", err)
+				t.Errorf("want\n%v", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResultsSuppress(t *testing.T) {
+	mysql := errorMatched("MySQL error")
+	general := errorMatched("General error")
+	id := scanner.Parameter{Parameter: "id", Attacks: []string{"SQLi"}}
+	page := scanner.Parameter{Parameter: "page"}
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		entries        []scanner.BaselineEntry
+		wantErrors     []scanner.ErrorMatched
+		wantParameters []scanner.Parameter
+	}{
+		{
+			name:           "empty baseline",
+			entries:        []scanner.BaselineEntry{},
+			wantErrors:     []scanner.ErrorMatched{mysql, general},
+			wantParameters: []scanner.Parameter{id, page},
+		},
+		{
+			name: "accepted findings",
+			entries: []scanner.BaselineEntry{
+				{Fingerprint: mysql.Fingerprint(), Status: scanner.BaselineAccepted},
+				{Fingerprint: scanner.ParameterFingerprint("http://example.com", page), Status: scanner.BaselineFalsePositive},
+			},
+			wantErrors:     []scanner.ErrorMatched{general},
+			wantParameters: []scanner.Parameter{id},
+		},
+		{
+			name: "expiring today",
+			entries: []scanner.BaselineEntry{
+				{Fingerprint: mysql.Fingerprint(), Status: scanner.BaselineAccepted, Expires: "2026-06-15"},
+			},
+			wantErrors:     []scanner.ErrorMatched{general},
+			wantParameters: []scanner.Parameter{id, page},
+		},
+		{
+			name: "expired entries",
+			entries: []scanner.BaselineEntry{
+				{Fingerprint: mysql.Fingerprint(), Status: scanner.BaselineAccepted, Expires: "2026-06-14"},
+				{Fingerprint: general.Fingerprint(), Status: scanner.BaselineAccepted, Expires: "2025-01-01"},
+			},
+			wantErrors:     []scanner.ErrorMatched{mysql, general},
+			wantParameters: []scanner.Parameter{id, page},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := &scanner.Results{
+				Endpoints: []scanner.EndpointMatched{{Parameters: []scanner.Parameter{id, page}, URL: "http://example.com"}},
+				Errors:    []scanner.ErrorMatched{mysql, general},
+			}
+			results.Suppress(scanner.NewBaseline(tt.entries), now)
+
+			if !reflect.DeepEqual(results.Errors, tt.wantErrors) {
+				t.Errorf("Results.Suppress\n%v", results.Errors)
+				t.Errorf("want\n%v", tt.wantErrors)
+			}
+
+			if !reflect.DeepEqual(results.Endpoints[0].Parameters, tt.wantParameters) {
+				t.Errorf("Results.Suppress\n%v", results.Endpoints[0].Parameters)
+				t.Errorf("want\n%v", tt.wantParameters)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+// BucketRegex struct.
+// Provider = the cloud storage provider.
+// Regex = the regular expression matching the storage URLs.
+// The named groups bucket, account, container and region
+// capture the parts of the storage location.
+type BucketRegex struct {
+	Provider string
+	Regex    string
+}
+
+// Bucket struct.
+// Provider = the cloud storage provider.
+// Name = the bucket name (account/container for Azure).
+// Region = the region (if present in the URL).
+// Status = the result of the unauthenticated list check (if performed).
+type Bucket struct {
+	Provider string `json:"provider"`
+	Name     string `json:"name"`
+	Region   string `json:"region,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// BucketMatched struct.
+// Bucket = Bucket struct.
+// Url = url in which the bucket is found.
+// Match = the string matching the regex.
+type BucketMatched struct {
+	Bucket Bucket
+	URL    string
+	Match  string
+}
+
+// Cloud storage providers.
+const (
+	BucketS3    = "AWS S3"
+	BucketGCS   = "Google Cloud Storage"
+	BucketAzure = "Azure Blob Storage"
+	BucketDO    = "DigitalOcean Spaces"
+)
+
+// Unauthenticated list check results.
+const (
+	BucketListable    = "publicly listable"
+	BucketNotListable = "not listable"
+	BucketNotFound    = "not found"
+)
+
+// GetBucketRegexes returns the regexes matching the URL and
+// hostname forms of the cloud storage services.
+func GetBucketRegexes() []BucketRegex {
+	return []BucketRegex{
+		{
+			BucketS3,
+			`(?i)(?P<bucket>[a-z0-9][a-z0-9.-]{1,61}[a-z0-9])\.s3(?:-website)?` +
+				`(?:[.-](?:dualstack\.)?(?P<region>[a-z]{2}(?:-[a-z]+)+-\d))?\.amazonaws\.com`,
+		},
+		{
+			BucketS3,
+			`(?i)(?:^|[^a-z0-9.-])s3(?:[.-](?:dualstack\.)?(?P<region>[a-z]{2}(?:-[a-z]+)+-\d))?\.amazonaws\.com/` +
+				`(?P<bucket>[a-z0-9][a-z0-9.-]{1,61}[a-z0-9])`,
+		},
+		{
+			BucketS3,
+			`(?i)(?:s3://|arn:aws:s3:::)(?P<bucket>[a-z0-9][a-z0-9.-]{1,61}[a-z0-9])`,
+		},
+		{
+			BucketGCS,
+			`(?i)(?P<bucket>[a-z0-9][a-z0-9._-]{1,61}[a-z0-9])\.storage\.googleapis\.com`,
+		},
+		{
+			BucketGCS,
+			`(?i)(?:^|[^a-z0-9.-])storage\.(?:googleapis|cloud\.google)\.com/` +
+				`(?P<bucket>[a-z0-9][a-z0-9._-]{1,61}[a-z0-9])`,
+		},
+		{
+			BucketGCS,
+			`(?i)gs://(?P<bucket>[a-z0-9][a-z0-9._-]{1,61}[a-z0-9])`,
+		},
+		{
+			BucketAzure,
+			`(?i)(?P<account>[a-z0-9]{3,24})\.blob\.core\.windows\.net(?:/(?P<container>[a-z0-9][a-z0-9-]{2,62}))?`,
+		},
+		{
+			BucketDO,
+			`(?i)(?P<bucket>[a-z0-9][a-z0-9-]{1,61}[a-z0-9])\.(?P<region>[a-z]{3}\d)(?:\.cdn)?\.digitaloceanspaces\.com`,
+		},
+		{
+			BucketDO,
+			`(?i)(?:^|[^a-z0-9.-])(?P<region>[a-z]{3}\d)\.digitaloceanspaces\.com/` +
+				`(?P<bucket>[a-z0-9][a-z0-9-]{1,61}[a-z0-9])`,
+		},
+	}
+}
+
+// RemoveDuplicateBuckets removes duplicates from Buckets found.
+func RemoveDuplicateBuckets(input []BucketMatched) []BucketMatched {
+	keys := make(map[string]bool)
+	list := []BucketMatched{}
+
+	for _, entry := range input {
+		key := entry.Bucket.Provider + entry.Bucket.Name
+		if _, value := keys[key]; !value {
+			keys[key] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// GetBucketSeverities returns the severity of the bucket statuses.
+// A bucket not found can be taken over.
+func GetBucketSeverities() map[string]string {
+	return map[string]string{
+		BucketListable:    SeverityHigh,
+		BucketNotFound:    SeverityMedium,
+		BucketNotListable: SeverityLow,
+	}
+}
+
+// Severity returns the severity of the bucket found.
+func (b BucketMatched) Severity() string {
+	return MaxSeverity(GetBucketSeverities()[b.Bucket.Status])
+}
+
+// Confidence returns the confidence of the bucket found
+// (high if its status has been checked).
+func (b BucketMatched) Confidence() string {
+	if b.Bucket.Status != "" {
+		return ConfidenceHigh
+	}
+
+	return ConfidenceMedium
+}
+
+// Fingerprint returns the stable fingerprint of the bucket found
+// (the same for all the pages referencing the bucket).
+func (b BucketMatched) Fingerprint() string {
+	return FindingFingerprint("bucket:"+b.Bucket.Provider, b.Bucket.Name, b.Bucket.Name)
+}
@@ -0,0 +1,143 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import "strings"
+
+// Cookie struct.
+// Name = the cookie name.
+// Domain, Path = the cookie scope attributes.
+// Issues = the weaknesses found in the cookie.
+// JWTIssues = the weaknesses of the JWT value.
+// Format = the format of the serialized object value.
+// Decoded = the decoded value (JWTs and serialized objects).
+type Cookie struct {
+	Name      string   `json:"name"`
+	Domain    string   `json:"domain,omitempty"`
+	Path      string   `json:"path,omitempty"`
+	Issues    []string `json:"issues,omitempty"`
+	JWTIssues []string `json:"jwt_issues,omitempty"`
+	Format    string   `json:"format,omitempty"`
+	Decoded   string   `json:"decoded,omitempty"`
+}
+
+// CookieMatched struct.
+// Cookie = Cookie struct.
+// Host = host setting the cookie.
+// Url = first url in which the cookie is set.
+// Match = the Set-Cookie header value.
+type CookieMatched struct {
+	Cookie Cookie
+	Host   string
+	URL    string
+	Match  string
+}
+
+// Cookie issues.
+const (
+	CookieNoSecure        = "Missing Secure"
+	CookieNoHTTPOnly      = "Missing HttpOnly"
+	CookieNoSameSite      = "Missing SameSite"
+	CookieSameSiteNone    = "SameSite=None without Secure"
+	CookieBroadDomain     = "Domain shared with subdomains"
+	CookieBroadPath       = "Path broader than the setting page"
+	CookieLongExpiry      = "Expiry longer than one year"
+	CookieSessionOverHTTP = "Session cookie sent over plain HTTP"
+	CookieJWT             = "JWT value"
+	CookieSerialized      = "Serialized object value"
+)
+
+// GetSessionCookieNames returns (lowercase) substrings that
+// identify session cookies.
+func GetSessionCookieNames() []string {
+	return []string{
+		"sess",
+		"sid",
+		"token",
+		"auth",
+		"jwt",
+		"login",
+		"remember",
+		"csrf",
+		"xsrf",
+	}
+}
+
+// RemoveDuplicateCookies removes duplicates from Cookies found.
+// Cookies are deduplicated per cookie name per host.
+func RemoveDuplicateCookies(input []CookieMatched) []CookieMatched {
+	keys := make(map[string]bool)
+	list := []CookieMatched{}
+
+	for _, entry := range input {
+		key := entry.Host + entry.Cookie.Name
+		if _, value := keys[key]; !value {
+			keys[key] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// GetCookieIssueSeverities returns the severity of the cookie issues
+// more severe than info.
+func GetCookieIssueSeverities() map[string]string {
+	return map[string]string{
+		CookieSessionOverHTTP: SeverityMedium,
+		CookieSerialized:      SeverityMedium,
+		CookieNoSecure:        SeverityLow,
+		CookieNoHTTPOnly:      SeverityLow,
+		CookieSameSiteNone:    SeverityLow,
+	}
+}
+
+// Severity returns the severity of the cookie found
+// (the highest severity of its issues and of its JWT weaknesses).
+func (c CookieMatched) Severity() string {
+	severities := []string{}
+	for _, issue := range c.Cookie.Issues {
+		severities = append(severities, GetCookieIssueSeverities()[issue])
+	}
+
+	for _, issue := range c.Cookie.JWTIssues {
+		severities = append(severities, GetJWTIssueSeverities()[issue])
+	}
+
+	return MaxSeverity(severities...)
+}
+
+// Confidence returns the confidence of the cookie found
+// (the cookies are always read from the response).
+func (c CookieMatched) Confidence() string {
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the cookie found.
+func (c CookieMatched) Fingerprint() string {
+	return FindingFingerprint("cookie:"+c.Cookie.Name, c.Host, strings.Join(c.Cookie.Issues, ","))
+}
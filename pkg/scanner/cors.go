@@ -0,0 +1,104 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+// CORS struct.
+// Name = the name that identifies the misconfiguration.
+// Origin = the crafted Origin header sent.
+// Severity = the severity of the misconfiguration.
+type CORS struct {
+	Name     string `json:"name"`
+	Origin   string `json:"origin"`
+	Severity string `json:"severity"`
+}
+
+// CORSMatched struct.
+// CORS = CORS struct.
+// Url = url trusting the crafted origin.
+// Match = the Access-Control-Allow-* headers returned.
+type CORSMatched struct {
+	CORS  CORS
+	URL   string
+	Match string
+}
+
+// CORS misconfigurations.
+const (
+	CORSArbitraryOrigin = "Arbitrary origin trusted"
+	CORSNullOrigin      = "null origin trusted"
+	CORSPrefixOrigin    = "Origin prefix match"
+	CORSSuffixOrigin    = "Origin suffix match"
+	CORSSubdomainOrigin = "Arbitrary subdomain trusted"
+	CORSInsecureOrigin  = "Insecure scheme origin trusted"
+)
+
+// CORSAttackerDomain is the domain used to craft the origins.
+const CORSAttackerDomain = "cariddi-attacker.com"
+
+// GetCORSOrigins returns the crafted origins to be
+// tested against a host.
+func GetCORSOrigins(host string) []CORS {
+	return []CORS{
+		{CORSArbitraryOrigin, "https://" + CORSAttackerDomain, SeverityHigh},
+		{CORSNullOrigin, "null", SeverityHigh},
+		{CORSPrefixOrigin, "https://" + host + "." + CORSAttackerDomain, SeverityHigh},
+		{CORSSuffixOrigin, "https://" + "cariddi" + host, SeverityHigh},
+		{CORSSubdomainOrigin, "https://cariddi." + host, SeverityMedium},
+		{CORSInsecureOrigin, "http://" + host, SeverityMedium},
+	}
+}
+
+// RemoveDuplicateCORS removes duplicates from CORS misconfigurations found.
+func RemoveDuplicateCORS(input []CORSMatched) []CORSMatched {
+	keys := make(map[string]bool)
+	list := []CORSMatched{}
+
+	for _, entry := range input {
+		if _, value := keys[entry.URL+entry.CORS.Name]; !value {
+			keys[entry.URL+entry.CORS.Name] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// Severity returns the severity of the CORS misconfiguration found.
+func (c CORSMatched) Severity() string {
+	return c.CORS.Severity
+}
+
+// Confidence returns the confidence of the CORS misconfiguration
+// found (the origin is verified with a request).
+func (c CORSMatched) Confidence() string {
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the CORS misconfiguration found.
+func (c CORSMatched) Fingerprint() string {
+	return FindingFingerprint("cors:"+c.CORS.Name, c.URL, c.CORS.Origin)
+}
@@ -29,166 +29,314 @@ package scanner
 // Parameter struct.
 // Parameter = the name of the parameter.
 // Attacks = Possible attacks.
+// Value = the value of the parameter in the endpoint.
+// ValueType = the kind of value (url, path, number, email, base64, json).
+// OffSite = the value is a URL pointing to another host.
+// Reflected = the value is reflected in the response body.
+// Confidence = confidence of the attacks suggested.
 type Parameter struct {
-	Parameter string   `json:"name"`
-	Attacks   []string `json:"attacks"`
+	Parameter   string   `json:"name"`
+	Attacks     []string `json:"attacks"`
+	Value       string   `json:"value,omitempty"`
+	ValueType   string   `json:"value_type,omitempty"`
+	OffSite     bool     `json:"off_site,omitempty"`
+	Reflected   bool     `json:"reflected,omitempty"`
+	Confidence  string   `json:"confidence,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
+}
+
+// Parameter value types.
+const (
+	ValueURL    = "url"
+	ValuePath   = "path"
+	ValueNumber = "number"
+	ValueEmail  = "email"
+	ValueBase64 = "base64"
+	ValueJSON   = "json"
+)
+
+// JuicyPath struct.
+// Name = the name of the juicy path.
+// Regex = regular expression matching the URL path.
+// Category = the attack or issue category.
+// Severity = severity of the finding.
+type JuicyPath struct {
+	Name     string `json:"name"`
+	Regex    string `json:"-"`
+	Category string `json:"category"`
+	Severity string `json:"severity"`
 }
 
 // EndpointMatched struct.
 // Parameters = a list of parameters in a particular endpoint.
+// Paths = a list of juicy paths matching the endpoint.
 // Url = url (aka endpoint).
 type EndpointMatched struct {
 	Parameters []Parameter
+	Paths      []JuicyPath
 	URL        string
 }
 
 // GetJuicyParameters returns juicy parameters and their possible attacks.
 func GetJuicyParameters() []Parameter {
 	var juicyParameters = []Parameter{
-		{"apikey", []string{"Info"}},
-		{"api_key", []string{"Info"}},
-		{"api-key", []string{"Info"}},
-		{"key", []string{"Info", "XSS"}},
-		{"token", []string{"Info"}},
-		{"secret", []string{"Info"}},
-		{"user-id", []string{"Info"}},
-		{"user_id", []string{"Info"}},
-		{"userid", []string{"Info"}},
-		{"auth", []string{"Info"}},
-		{"admin", []string{"Info"}},
-		{"dashboard", []string{"Info"}},
-		{"manage", []string{"Info"}},
-		{"debug", []string{"Info"}},
-		{"dbg", []string{"Info"}},
-		{"uid", []string{"Info"}},
-		{"root", []string{"Info"}},
-		{"shell", []string{"Info"}},
-		{"id", []string{"SQLi", "XSS"}},
-		{"page", []string{"SQLi", "LFI", "SSRF", "XSS"}},
-		{"dir", []string{"SQLi", "LFI", "SSRF"}},
-		{"search", []string{"SQLi", "XSS"}},
-		{"category", []string{"SQLi", "XSS"}},
-		{"file", []string{"SQLi", "LFI"}},
-		{"class", []string{"SQLi"}},
-		{"url", []string{"SQLi", "OpenRedir", "SSRF", "XSS"}},
-		{"news", []string{"SQLi"}},
-		{"item", []string{"SQLi"}},
-		{"menu", []string{"SQLi"}},
-		{"lang", []string{"SQLi", "XSS"}},
-		{"name", []string{"SQLi", "XSS"}},
-		{"ref", []string{"SQLi"}},
-		{"title", []string{"SQLi"}},
-		{"view", []string{"SQLi", "LFI", "OpenRedir", "SSRF", "XSS"}},
-		{"topic", []string{"SQLi"}},
-		{"thread", []string{"SQLi"}},
-		{"type", []string{"SQLi", "LFI", "XSS"}},
-		{"date", []string{"SQLi", "LFI", "XSS"}},
-		{"img", []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
-		{"img_url", []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
-		{"img-url", []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
-		{"img-src", []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
-		{"src", []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
-		{"form", []string{"SQLi"}},
-		{"join", []string{"SQLi"}},
-		{"main", []string{"SQLi"}},
-		{"nav", []string{"SQLi"}},
-		{"region", []string{"SQLi"}},
-		{"cat", []string{"LFI"}},
-		{"action", []string{"LFI"}},
-		{"board", []string{"LFI"}},
-		{"detail", []string{"LFI"}},
-		{"download", []string{"LFI"}},
-		{"path", []string{"LFI", "SSRF"}},
-		{"folder", []string{"LFI"}},
-		{"prefix", []string{"LFI"}},
-		{"include", []string{"LFI"}},
-		{"inc", []string{"LFI"}},
-		{"locate", []string{"LFI"}},
-		{"show", []string{"LFI"}},
-		{"doc", []string{"LFI"}},
-		{"site", []string{"LFI", "SSRF"}},
-		{"content", []string{"LFI"}},
-		{"document", []string{"LFI"}},
-		{"layout", []string{"LFI"}},
-		{"mod", []string{"LFI"}},
-		{"conf", []string{"LFI"}},
-		{"next", []string{"OpenRedir", "SSRF"}},
-		{"target", []string{"OpenRedir"}},
-		{"rurl", []string{"OpenRedir", "SSRF"}},
-		{"dest", []string{"OpenRedir", "SSRF"}},
-		{"destination", []string{"OpenRedir"}},
-		{"redir", []string{"OpenRedir", "SSRF"}},
-		{"redirect_uri", []string{"OpenRedir", "SSRF"}},
-		{"redirect_url", []string{"OpenRedir", "SSRF"}},
-		{"redirect", []string{"OpenRedir", "SSRF"}},
-		{"go", []string{"OpenRedir", "SSRF"}},
-		{"return", []string{"OpenRedir", "SSRF"}},
-		{"continue", []string{"OpenRedir", "SSRF"}},
-		{"image_url", []string{"OpenRedir", "SSRF"}},
-		{"returnto", []string{"OpenRedir"}},
-		{"return_to", []string{"OpenRedir"}},
-		{"checkout_url", []string{"OpenRedir", "SSRF"}},
-		{"return_path", []string{"OpenRedir"}},
-		{"out", []string{"OpenRedir", "SSRF"}},
-		{"exec", []string{"RCE"}},
-		{"cmd", []string{"RCE"}},
-		{"command", []string{"RCE"}},
-		{"execute", []string{"RCE"}},
-		{"ping", []string{"RCE"}},
-		{"query", []string{"RCE", "XSS"}},
-		{"reg", []string{"RCE"}},
-		{"do", []string{"RCE"}},
-		{"func", []string{"RCE"}},
-		{"arg", []string{"RCE"}},
-		{"jump", []string{"RCE"}},
-		{"code", []string{"RCE"}},
-		{"option", []string{"RCE"}},
-		{"load", []string{"RCE"}},
-		{"option", []string{"RCE"}},
-		{"process", []string{"RCE"}},
-		{"step", []string{"RCE"}},
-		{"read", []string{"RCE", "LFI"}},
-		{"function", []string{"RCE"}},
-		{"req", []string{"RCE"}},
-		{"feature", []string{"RCE"}},
-		{"exe", []string{"RCE"}},
-		{"module", []string{"RCE"}},
-		{"payload", []string{"RCE"}},
-		{"run", []string{"RCE"}},
-		{"print", []string{"RCE"}},
-		{"uri", []string{"SSRF"}},
-		{"window", []string{"SSRF"}},
-		{"data", []string{"SSRF"}},
-		{"reference", []string{"SSRF"}},
-		{"html", []string{"SSRF"}},
-		{"val", []string{"SSRF"}},
-		{"validate", []string{"SSRF"}},
-		{"domain", []string{"SSRF"}},
-		{"callback", []string{"SSRF"}},
-		{"feed", []string{"SSRF"}},
-		{"port", []string{"SSRF"}},
-		{"to", []string{"SSRF"}},
-		{"host", []string{"SSRF"}},
-		{"q", []string{"XSS"}},
-		{"keyword", []string{"XSS"}},
-		{"keywords", []string{"XSS"}},
-		{"month", []string{"XSS"}},
-		{"year", []string{"XSS"}},
-		{"email", []string{"XSS", "SQLi"}},
-		{"terms", []string{"XSS"}},
-		{"term", []string{"XSS"}},
-		{"begindate", []string{"XSS"}},
-		{"enddate", []string{"XSS"}},
-		{"categoryid", []string{"XSS", "SQLi"}},
-		{"p", []string{"XSS"}},
-		{"l", []string{"XSS"}},
-		{"s", []string{"XSS"}},
-		{"list_type", []string{"XSS"}},
+		{Parameter: "apikey", Attacks: []string{"Info"}},
+		{Parameter: "api_key", Attacks: []string{"Info"}},
+		{Parameter: "api-key", Attacks: []string{"Info"}},
+		{Parameter: "key", Attacks: []string{"Info", "XSS"}},
+		{Parameter: "token", Attacks: []string{"Info"}},
+		{Parameter: "secret", Attacks: []string{"Info"}},
+		{Parameter: "user-id", Attacks: []string{"Info"}},
+		{Parameter: "user_id", Attacks: []string{"Info"}},
+		{Parameter: "userid", Attacks: []string{"Info"}},
+		{Parameter: "auth", Attacks: []string{"Info"}},
+		{Parameter: "admin", Attacks: []string{"Info"}},
+		{Parameter: "dashboard", Attacks: []string{"Info"}},
+		{Parameter: "manage", Attacks: []string{"Info"}},
+		{Parameter: "debug", Attacks: []string{"Info"}},
+		{Parameter: "dbg", Attacks: []string{"Info"}},
+		{Parameter: "uid", Attacks: []string{"Info"}},
+		{Parameter: "root", Attacks: []string{"Info"}},
+		{Parameter: "shell", Attacks: []string{"Info"}},
+		{Parameter: "id", Attacks: []string{"SQLi", "XSS"}},
+		{Parameter: "page", Attacks: []string{"SQLi", "LFI", "SSRF", "XSS"}},
+		{Parameter: "dir", Attacks: []string{"SQLi", "LFI", "SSRF"}},
+		{Parameter: "search", Attacks: []string{"SQLi", "XSS"}},
+		{Parameter: "category", Attacks: []string{"SQLi", "XSS"}},
+		{Parameter: "file", Attacks: []string{"SQLi", "LFI"}},
+		{Parameter: "class", Attacks: []string{"SQLi"}},
+		{Parameter: "url", Attacks: []string{"SQLi", "OpenRedir", "SSRF", "XSS"}},
+		{Parameter: "news", Attacks: []string{"SQLi"}},
+		{Parameter: "item", Attacks: []string{"SQLi"}},
+		{Parameter: "menu", Attacks: []string{"SQLi"}},
+		{Parameter: "lang", Attacks: []string{"SQLi", "XSS"}},
+		{Parameter: "name", Attacks: []string{"SQLi", "XSS"}},
+		{Parameter: "ref", Attacks: []string{"SQLi"}},
+		{Parameter: "title", Attacks: []string{"SQLi"}},
+		{Parameter: "view", Attacks: []string{"SQLi", "LFI", "OpenRedir", "SSRF", "XSS"}},
+		{Parameter: "topic", Attacks: []string{"SQLi"}},
+		{Parameter: "thread", Attacks: []string{"SQLi"}},
+		{Parameter: "type", Attacks: []string{"SQLi", "LFI", "XSS"}},
+		{Parameter: "date", Attacks: []string{"SQLi", "LFI", "XSS"}},
+		{Parameter: "img", Attacks: []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
+		{Parameter: "img_url", Attacks: []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
+		{Parameter: "img-url", Attacks: []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
+		{Parameter: "img-src", Attacks: []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
+		{Parameter: "src", Attacks: []string{"OpenRedir", "SSRF", "SQLi", "LFI", "XSS"}},
+		{Parameter: "form", Attacks: []string{"SQLi"}},
+		{Parameter: "join", Attacks: []string{"SQLi"}},
+		{Parameter: "main", Attacks: []string{"SQLi"}},
+		{Parameter: "nav", Attacks: []string{"SQLi"}},
+		{Parameter: "region", Attacks: []string{"SQLi"}},
+		{Parameter: "cat", Attacks: []string{"LFI"}},
+		{Parameter: "action", Attacks: []string{"LFI"}},
+		{Parameter: "board", Attacks: []string{"LFI"}},
+		{Parameter: "detail", Attacks: []string{"LFI"}},
+		{Parameter: "download", Attacks: []string{"LFI"}},
+		{Parameter: "path", Attacks: []string{"LFI", "SSRF"}},
+		{Parameter: "folder", Attacks: []string{"LFI"}},
+		{Parameter: "prefix", Attacks: []string{"LFI"}},
+		{Parameter: "include", Attacks: []string{"LFI"}},
+		{Parameter: "inc", Attacks: []string{"LFI"}},
+		{Parameter: "locate", Attacks: []string{"LFI"}},
+		{Parameter: "show", Attacks: []string{"LFI"}},
+		{Parameter: "doc", Attacks: []string{"LFI"}},
+		{Parameter: "site", Attacks: []string{"LFI", "SSRF"}},
+		{Parameter: "content", Attacks: []string{"LFI"}},
+		{Parameter: "document", Attacks: []string{"LFI"}},
+		{Parameter: "layout", Attacks: []string{"LFI"}},
+		{Parameter: "mod", Attacks: []string{"LFI"}},
+		{Parameter: "conf", Attacks: []string{"LFI"}},
+		{Parameter: "next", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "target", Attacks: []string{"OpenRedir"}},
+		{Parameter: "rurl", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "dest", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "destination", Attacks: []string{"OpenRedir"}},
+		{Parameter: "redir", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "redirect_uri", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "redirect_url", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "redirect", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "go", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "return", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "continue", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "image_url", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "returnto", Attacks: []string{"OpenRedir"}},
+		{Parameter: "return_to", Attacks: []string{"OpenRedir"}},
+		{Parameter: "checkout_url", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "return_path", Attacks: []string{"OpenRedir"}},
+		{Parameter: "out", Attacks: []string{"OpenRedir", "SSRF"}},
+		{Parameter: "exec", Attacks: []string{"RCE"}},
+		{Parameter: "cmd", Attacks: []string{"RCE"}},
+		{Parameter: "command", Attacks: []string{"RCE"}},
+		{Parameter: "execute", Attacks: []string{"RCE"}},
+		{Parameter: "ping", Attacks: []string{"RCE"}},
+		{Parameter: "query", Attacks: []string{"RCE", "XSS"}},
+		{Parameter: "reg", Attacks: []string{"RCE"}},
+		{Parameter: "do", Attacks: []string{"RCE"}},
+		{Parameter: "func", Attacks: []string{"RCE"}},
+		{Parameter: "arg", Attacks: []string{"RCE"}},
+		{Parameter: "jump", Attacks: []string{"RCE"}},
+		{Parameter: "code", Attacks: []string{"RCE"}},
+		{Parameter: "option", Attacks: []string{"RCE"}},
+		{Parameter: "load", Attacks: []string{"RCE"}},
+		{Parameter: "option", Attacks: []string{"RCE"}},
+		{Parameter: "process", Attacks: []string{"RCE"}},
+		{Parameter: "step", Attacks: []string{"RCE"}},
+		{Parameter: "read", Attacks: []string{"RCE", "LFI"}},
+		{Parameter: "function", Attacks: []string{"RCE"}},
+		{Parameter: "req", Attacks: []string{"RCE"}},
+		{Parameter: "feature", Attacks: []string{"RCE"}},
+		{Parameter: "exe", Attacks: []string{"RCE"}},
+		{Parameter: "module", Attacks: []string{"RCE"}},
+		{Parameter: "payload", Attacks: []string{"RCE"}},
+		{Parameter: "run", Attacks: []string{"RCE"}},
+		{Parameter: "print", Attacks: []string{"RCE"}},
+		{Parameter: "uri", Attacks: []string{"SSRF"}},
+		{Parameter: "window", Attacks: []string{"SSRF"}},
+		{Parameter: "data", Attacks: []string{"SSRF"}},
+		{Parameter: "reference", Attacks: []string{"SSRF"}},
+		{Parameter: "html", Attacks: []string{"SSRF"}},
+		{Parameter: "val", Attacks: []string{"SSRF"}},
+		{Parameter: "validate", Attacks: []string{"SSRF"}},
+		{Parameter: "domain", Attacks: []string{"SSRF"}},
+		{Parameter: "callback", Attacks: []string{"SSRF"}},
+		{Parameter: "feed", Attacks: []string{"SSRF"}},
+		{Parameter: "port", Attacks: []string{"SSRF"}},
+		{Parameter: "to", Attacks: []string{"SSRF"}},
+		{Parameter: "host", Attacks: []string{"SSRF"}},
+		{Parameter: "q", Attacks: []string{"XSS"}},
+		{Parameter: "keyword", Attacks: []string{"XSS"}},
+		{Parameter: "keywords", Attacks: []string{"XSS"}},
+		{Parameter: "month", Attacks: []string{"XSS"}},
+		{Parameter: "year", Attacks: []string{"XSS"}},
+		{Parameter: "email", Attacks: []string{"XSS", "SQLi"}},
+		{Parameter: "terms", Attacks: []string{"XSS"}},
+		{Parameter: "term", Attacks: []string{"XSS"}},
+		{Parameter: "begindate", Attacks: []string{"XSS"}},
+		{Parameter: "enddate", Attacks: []string{"XSS"}},
+		{Parameter: "categoryid", Attacks: []string{"XSS", "SQLi"}},
+		{Parameter: "p", Attacks: []string{"XSS"}},
+		{Parameter: "l", Attacks: []string{"XSS"}},
+		{Parameter: "s", Attacks: []string{"XSS"}},
+		{Parameter: "list_type", Attacks: []string{"XSS"}},
 	}
 
 	return juicyParameters
 }
 
+// ParameterConfidence returns the confidence of the attacks suggested
+// for a parameter. Every signal supporting an attack (a value of the
+// expected kind, an off-site URL, a reflected value) raises it.
+func ParameterConfidence(parameter Parameter) string {
+	signals := 0
+
+	switch parameter.ValueType {
+	case ValueURL:
+		if hasAttack(parameter, "OpenRedir", "SSRF") {
+			signals++
+
+			if parameter.OffSite {
+				signals++
+			}
+		}
+	case ValuePath:
+		if hasAttack(parameter, "LFI", "RCE") {
+			signals++
+		}
+	case ValueNumber:
+		if hasAttack(parameter, "SQLi") {
+			signals++
+		}
+	case ValueBase64, ValueJSON:
+		if hasAttack(parameter, "RCE", "SQLi") {
+			signals++
+		}
+	}
+
+	if parameter.Reflected && hasAttack(parameter, "XSS") {
+		signals++
+	}
+
+	switch {
+	case signals == 0:
+		return ConfidenceLow
+	case signals == 1:
+		return ConfidenceMedium
+	default:
+		return ConfidenceHigh
+	}
+}
+
+// hasAttack checks if at least one of the attacks is
+// suggested for a parameter.
+func hasAttack(parameter Parameter, attacks ...string) bool {
+	for _, attack := range parameter.Attacks {
+		for _, elem := range attacks {
+			if attack == elem {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// GetJuicyPaths returns juicy paths, their categories and severities.
+// The regexes are matched against the path of every URL crawled.
+func GetJuicyPaths() []JuicyPath {
+	var juicyPaths = []JuicyPath{
+		// Admin panels.
+		{"Admin panel", `(?i)/(admin|administrator|admin-console|backoffice)(/|\.php$|$)`, "Admin", SeverityLow},
+		{"Database admin panel", `(?i)/(phpmyadmin|pma|adminer(\.php)?)(/|$)`, "Admin", SeverityMedium},
+		{"Tomcat manager", `(?i)/(manager/(html|text|status)|host-manager)(/|$)`, "Admin", SeverityMedium},
+		{"WordPress admin", `(?i)/wp-(admin(/|$)|login\.php$)`, "Admin", SeverityInfo},
+		// Debug and monitoring endpoints.
+		{"Spring Boot Actuator", `(?i)/actuator(/(health|info|metrics|mappings|beans|loggers|conditions))?/?$`,
+			"Debug", SeverityLow},
+		{"Spring Boot Actuator (sensitive)",
+			`(?i)/actuator/(env|heapdump|configprops|threaddump|jolokia|gateway|httptrace|trace)(/|$)`,
+			"Debug", SeverityHigh},
+		{"Go pprof", `(?i)/debug/pprof(/|$)`, "Debug", SeverityHigh},
+		{"Go expvar", `(?i)/debug/vars/?$`, "Debug", SeverityMedium},
+		{"phpinfo", `(?i)/php_?info\.php$`, "Debug", SeverityMedium},
+		{"Apache server status", `(?i)/server-(status|info)/?$`, "Debug", SeverityMedium},
+		{"Symfony profiler", `(?i)/(_profiler|_wdt)(/|$)`, "Debug", SeverityHigh},
+		{"Laravel Telescope/Horizon", `(?i)/(telescope|horizon)(/|$)`, "Debug", SeverityMedium},
+		{"ASP.NET trace", `(?i)/(elmah|trace)\.axd$`, "Debug", SeverityHigh},
+		{"Jolokia", `(?i)/jolokia(/|$)`, "Debug", SeverityHigh},
+		{"Prometheus metrics", `(?i)/metrics/?$`, "Debug", SeverityLow},
+		// API documentation and consoles.
+		{"API documentation", `(?i)/(swagger-ui(\.html)?|api-docs|swagger\.(json|ya?ml)|openapi\.(json|ya?ml))(/|$)`,
+			"API docs", SeverityInfo},
+		{"GraphQL IDE", `(?i)/(graphiql|altair|graphql-playground)(/|$)`, "API docs", SeverityLow},
+		// Version control systems.
+		{"Git repository", `(?i)/\.git(/|$)`, "Source code", SeverityHigh},
+		{"Subversion repository", `(?i)/\.svn(/|$)`, "Source code", SeverityHigh},
+		{"Mercurial repository", `(?i)/\.hg(/|$)`, "Source code", SeverityHigh},
+		{"Bazaar repository", `(?i)/\.bzr(/|$)`, "Source code", SeverityHigh},
+		{"macOS folder metadata", `(?i)/\.ds_store$`, "Source code", SeverityLow},
+		// Configuration files.
+		{"Dotenv file", `(?i)/\.env(\.[a-z]+)?$`, "Config", SeverityHigh},
+		{"Apache access file", `(?i)/\.ht(access|passwd)$`, "Config", SeverityMedium},
+		{"IIS configuration", `(?i)/web\.config$`, "Config", SeverityMedium},
+		{"WordPress configuration backup", `(?i)/wp-config\.php.+$`, "Config", SeverityHigh},
+		{"Docker configuration", `(?i)/(docker-compose\.ya?ml|dockerfile)$`, "Config", SeverityLow},
+		{"Dependencies manifest", `(?i)/(package(-lock)?\.json|composer\.(json|lock)|yarn\.lock)$`, "Config", SeverityInfo},
+		// Credentials.
+		{"AWS credentials", `(?i)/\.aws/(credentials|config)$`, "Credentials", SeverityCritical},
+		{"Package manager credentials", `(?i)/\.(npmrc|pypirc|netrc|git-credentials)$`, "Credentials", SeverityHigh},
+		{"SSH private key", `(?i)/(id_rsa|id_dsa|id_ecdsa|id_ed25519)$`, "Credentials", SeverityCritical},
+		// Miscellaneous.
+		{"Installation script", `(?i)/(install|setup)\.php$`, "Installer", SeverityMedium},
+		{"Web shell", `(?i)/(shell|cmd|c99|r57|webshell)\.(php|asp|aspx|jsp)$`, "Web shell", SeverityCritical},
+		{"CGI directory", `(?i)/cgi-bin/`, "Info", SeverityInfo},
+		{"Cross-domain policy", `(?i)/(crossdomain|clientaccesspolicy)\.xml$`, "Info", SeverityInfo},
+	}
+
+	return juicyPaths
+}
+
 // RemovDuplicateEndpoints removes duplicate endpoints found.
 func RemovDuplicateEndpoints(input []EndpointMatched) []EndpointMatched {
 	keys := make(map[string]bool)
@@ -203,3 +351,62 @@ func RemovDuplicateEndpoints(input []EndpointMatched) []EndpointMatched {
 
 	return list
 }
+
+// ParameterSeverity returns the severity of a juicy parameter,
+// depending on the confidence of the attacks suggested.
+func ParameterSeverity(parameter Parameter) string {
+	if len(parameter.Attacks) == 0 {
+		return SeverityInfo
+	}
+
+	switch parameter.Confidence {
+	case ConfidenceHigh:
+		return SeverityMedium
+	case ConfidenceMedium:
+		return SeverityLow
+	}
+
+	return SeverityInfo
+}
+
+// Severity returns the severity of the endpoint found (the
+// highest severity of its parameters and paths).
+func (e EndpointMatched) Severity() string {
+	severities := []string{}
+	for _, parameter := range e.Parameters {
+		severities = append(severities, ParameterSeverity(parameter))
+	}
+
+	for _, path := range e.Paths {
+		severities = append(severities, path.Severity)
+	}
+
+	return MaxSeverity(severities...)
+}
+
+// Confidence returns the confidence of the endpoint found (the highest
+// confidence of its parameters; the paths are always matched exactly).
+func (e EndpointMatched) Confidence() string {
+	confidences := []string{}
+	for _, parameter := range e.Parameters {
+		confidences = append(confidences, parameter.Confidence)
+	}
+
+	if len(e.Paths) != 0 {
+		confidences = append(confidences, ConfidenceHigh)
+	}
+
+	return MaxConfidence(confidences...)
+}
+
+// ParameterFingerprint returns the stable fingerprint
+// of a juicy parameter found in an endpoint.
+func ParameterFingerprint(endpoint string, parameter Parameter) string {
+	return FindingFingerprint("parameter:"+parameter.Parameter, endpoint, parameter.Parameter)
+}
+
+// PathFingerprint returns the stable fingerprint
+// of a juicy path found in an endpoint.
+func PathFingerprint(endpoint string, path JuicyPath) string {
+	return FindingFingerprint("path:"+path.Name, endpoint, path.Name)
+}
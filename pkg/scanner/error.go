@@ -35,10 +35,12 @@ type Error struct {
 // Error = Error struct.
 // Url = url in which the error is found.
 // Match = the string matching the regex.
+// Region = the position of the match in the response body.
 type ErrorMatched struct {
-	Error Error
-	URL   string
-	Match string
+	Error  Error
+	URL    string
+	Match  string
+	Region *Region
 }
 
 // GetErrorRegexes returns all the error structs.
@@ -164,3 +166,44 @@ func RemoveDuplicateErrors(input []ErrorMatched) []ErrorMatched {
 
 	return list
 }
+
+// GetErrorRatings returns the rating of the errors whose severity or
+// confidence differs from the default one (low severity, medium confidence).
+// Database errors often point at injections.
+func GetErrorRatings() map[string]Rating {
+	return map[string]Rating{
+		"General error":     {SeverityLow, ConfidenceLow},
+		"Debug information": {SeverityMedium, ConfidenceMedium},
+		"MySQL error":       {SeverityMedium, ConfidenceMedium},
+		"MariaDB error":     {SeverityMedium, ConfidenceMedium},
+		"PostgreSQL error":  {SeverityMedium, ConfidenceMedium},
+		"MSSQL error":       {SeverityMedium, ConfidenceMedium},
+		"OracleDB error":    {SeverityMedium, ConfidenceMedium},
+		"IBMDB2 error":      {SeverityMedium, ConfidenceMedium},
+		"SQLite error":      {SeverityMedium, ConfidenceMedium},
+	}
+}
+
+// errorRating returns the rating of an error.
+func errorRating(name string) Rating {
+	if rating, ok := GetErrorRatings()[name]; ok {
+		return rating
+	}
+
+	return Rating{SeverityLow, ConfidenceMedium}
+}
+
+// Severity returns the severity of the error found.
+func (e ErrorMatched) Severity() string {
+	return errorRating(e.Error.ErrorName).Severity
+}
+
+// Confidence returns the confidence of the error found.
+func (e ErrorMatched) Confidence() string {
+	return errorRating(e.Error.ErrorName).Confidence
+}
+
+// Fingerprint returns the stable fingerprint of the error found.
+func (e ErrorMatched) Fingerprint() string {
+	return FindingFingerprint("error:"+e.Error.ErrorName, e.URL, e.Match)
+}
@@ -0,0 +1,180 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import (
+	"path"
+	"strings"
+)
+
+// Exposure struct.
+// Name = what is exposed (e.g. Git repository, source code backup).
+// Category = the kind of exposure (vcs, backup, config).
+// Severity = the severity, depending on what the content shows.
+// Evidence = what the content shows (secrets are never included).
+type Exposure struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	Evidence string `json:"evidence,omitempty"`
+}
+
+// ExposureMatched struct.
+// Exposure = Exposure struct.
+// Url = url of the exposed file.
+type ExposureMatched struct {
+	Exposure Exposure
+	URL      string
+}
+
+// Exposure categories.
+const (
+	ExposureVCS    = "vcs"
+	ExposureBackup = "backup"
+	ExposureConfig = "config"
+)
+
+// MagicBytes struct.
+// Extension = the file extension.
+// Offset = the offset of the signature.
+// Signature = the bytes identifying the file type.
+type MagicBytes struct {
+	Extension string
+	Offset    int
+	Signature string
+}
+
+// GetMagicBytes returns the signatures of the binary
+// file types whose content can be validated.
+func GetMagicBytes() []MagicBytes {
+	return []MagicBytes{
+		{"zip", 0, "PK\x03\x04"},
+		{"gz", 0, "\x1f\x8b"},
+		{"tar", 257, "ustar"},
+		{"rar", 0, "Rar!\x1a\x07"},
+		{"7z", 0, "7z\xbc\xaf\x27\x1c"},
+		{"bz2", 0, "BZh"},
+		{"db", 0, "SQLite format 3\x00"},
+		{"pdf", 0, "%PDF-"},
+		{"swp", 0, "b0VIM"},
+	}
+}
+
+// GetVCSFiles returns the version control files
+// probed (once) on every host.
+func GetVCSFiles() []string {
+	return []string{"/.git/HEAD", "/.git/config", "/.svn/wc.db", "/.hg/requires"}
+}
+
+// GetBackupSuffixes returns the suffixes of the backup
+// copies left by editors and administrators.
+func GetBackupSuffixes() []string {
+	return []string{"~", ".bak", ".old", ".orig", ".save", ".swp"}
+}
+
+// GetBackupExtensions returns the extensions of the files whose backup
+// copies are probed: server-side source code and configuration files,
+// whose content is never served as-is.
+func GetBackupExtensions() []string {
+	return []string{"php", "php5", "phtml", "asp", "aspx", "jsp", "py", "rb", "pl", "cgi",
+		"config", "conf", "ini", "env", "yml", "yaml", "properties"}
+}
+
+// GetBackupVariants returns the paths of the backup copies
+// of a file (e.g. index.php~, index.php.bak, .index.php.swp).
+func GetBackupVariants(filePath string) []string {
+	dir, file := path.Split(filePath)
+	variants := []string{}
+
+	for _, suffix := range GetBackupSuffixes() {
+		if suffix == ".swp" {
+			// Vim swap files are hidden.
+			variants = append(variants, dir+"."+file+suffix)
+			continue
+		}
+
+		variants = append(variants, filePath+suffix)
+	}
+
+	return variants
+}
+
+// BackupOriginal returns the path of the original file of a
+// backup copy and true, or false if the path isn't a backup copy.
+func BackupOriginal(filePath string) (string, bool) {
+	for _, suffix := range GetBackupSuffixes() {
+		if !strings.HasSuffix(filePath, suffix) || len(filePath) == len(suffix) {
+			continue
+		}
+
+		original := strings.TrimSuffix(filePath, suffix)
+
+		if suffix == ".swp" {
+			dir, file := path.Split(original)
+			if !strings.HasPrefix(file, ".") {
+				continue
+			}
+
+			original = dir + strings.TrimPrefix(file, ".")
+		}
+
+		return original, true
+	}
+
+	return "", false
+}
+
+// RemoveDuplicateExposures removes duplicates from exposures found.
+func RemoveDuplicateExposures(input []ExposureMatched) []ExposureMatched {
+	keys := make(map[string]bool)
+	list := []ExposureMatched{}
+
+	for _, entry := range input {
+		if _, value := keys[entry.URL]; !value {
+			keys[entry.URL] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// Severity returns the severity of the exposed file found.
+func (e ExposureMatched) Severity() string {
+	return e.Exposure.Severity
+}
+
+// Confidence returns the confidence of the exposed file
+// found (its content is always validated).
+func (e ExposureMatched) Confidence() string {
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the exposure found.
+func (e ExposureMatched) Fingerprint() string {
+	return FindingFingerprint("exposure:"+e.Exposure.Name, e.URL, e.Exposure.Evidence)
+}
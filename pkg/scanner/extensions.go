@@ -37,11 +37,28 @@ type FileType struct {
 // FileTypeMatched struct.
 // Filetype = Filetype struct.
 // Url = url of the file found.
+// Signal = the signal the file type was detected from.
 type FileTypeMatched struct {
 	Filetype FileType
 	URL      string
+	Signal   string
 }
 
+// ContentType struct.
+// MediaType = the media type served in the Content-Type header.
+// Extension = the file extension associated.
+type ContentType struct {
+	MediaType string
+	Extension string
+}
+
+const (
+	SignalDisposition = "content-disposition"
+	SignalMagicBytes  = "magic-bytes"
+	SignalContentType = "content-type"
+	SignalURL         = "url"
+)
+
 // GetExtensions returns all the extension structs.
 func GetExtensions() []FileType {
 	// extensions contains a list of known extensions
@@ -72,6 +89,8 @@ func GetExtensions() []FileType {
 		{"config", 2},
 		{"configs", 2},
 		{"apk", 2},
+		{"jar", 2},
+		{"war", 2},
 		{"cgi", 3},
 		{"sh", 3},
 		{"py", 3},
@@ -115,6 +134,46 @@ func GetExtensions() []FileType {
 	return extensions
 }
 
+// GetContentTypes returns the media types identifying a
+// downloadable file. Media types served by every web page
+// (HTML, JavaScript, JSON...) are not listed on purpose.
+func GetContentTypes() []ContentType {
+	return []ContentType{
+		{"application/pdf", "pdf"},
+		{"application/zip", "zip"},
+		{"application/x-zip-compressed", "zip"},
+		{"application/gzip", "gz"},
+		{"application/x-gzip", "gz"},
+		{"application/x-tar", "tar"},
+		{"application/x-gtar", "tar.gz"},
+		{"application/x-bzip2", "tar.bz2"},
+		{"application/vnd.rar", "rar"},
+		{"application/x-rar-compressed", "rar"},
+		{"application/x-7z-compressed", "7z"},
+		{"application/sql", "sql"},
+		{"application/x-sql", "sql"},
+		{"application/vnd.sqlite3", "db"},
+		{"application/x-sqlite3", "db"},
+		{"application/x-pem-file", "pem"},
+		{"application/x-x509-ca-cert", "pem"},
+		{"application/pkcs8", "key"},
+		{"application/x-openvpn-profile", "ovpn"},
+		{"application/vnd.android.package-archive", "apk"},
+		{"application/java-archive", "jar"},
+		{"application/msword", "doc"},
+		{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "docx"},
+		{"application/vnd.ms-excel", "xls"},
+		{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx"},
+		{"application/vnd.oasis.opendocument.text", "odt"},
+		{"text/csv", "csv"},
+		{"application/x-sh", "sh"},
+		{"text/x-python", "py"},
+		{"application/x-yaml", "yaml"},
+		{"application/yaml", "yaml"},
+		{"application/toml", "toml"},
+	}
+}
+
 // RemoveDuplicateExtensions removes duplicates from Extensions found.
 func RemoveDuplicateExtensions(input []FileTypeMatched) []FileTypeMatched {
 	keys := make(map[string]bool)
@@ -129,3 +188,41 @@ func RemoveDuplicateExtensions(input []FileTypeMatched) []FileTypeMatched {
 
 	return list
 }
+
+// FileTypeSeverity returns the severity of a file type
+// (1 is high, 7 is info).
+func FileTypeSeverity(filetype FileType) string {
+	switch {
+	case filetype.Severity == 1:
+		return SeverityHigh
+	case filetype.Severity == 2:
+		return SeverityMedium
+	case filetype.Severity == 3 || filetype.Severity == 4:
+		return SeverityLow
+	}
+
+	return SeverityInfo
+}
+
+// Severity returns the severity of the file found.
+func (f FileTypeMatched) Severity() string {
+	return FileTypeSeverity(f.Filetype)
+}
+
+// Confidence returns the confidence of the file found,
+// depending on the signal matched.
+func (f FileTypeMatched) Confidence() string {
+	switch f.Signal {
+	case SignalDisposition, SignalMagicBytes:
+		return ConfidenceHigh
+	case SignalContentType:
+		return ConfidenceMedium
+	}
+
+	return ConfidenceLow
+}
+
+// Fingerprint returns the stable fingerprint of the file type found.
+func (f FileTypeMatched) Fingerprint() string {
+	return FindingFingerprint("filetype:"+f.Filetype.Extension, f.URL, f.Filetype.Extension)
+}
@@ -0,0 +1,88 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Fingerprinted is a finding that can be identified across scans.
+type Fingerprinted interface {
+	Fingerprint() string
+}
+
+// FindingFingerprint returns a stable fingerprint of a finding, built from
+// the rule that matched, the normalized URL and the hash of the match.
+func FindingFingerprint(rule, target, match string) string {
+	matchHash := sha256.Sum256([]byte(match))
+	hash := sha256.Sum256([]byte(rule + "\n" + NormalizeURL(target) + "\n" + hex.EncodeToString(matchHash[:])))
+
+	return hex.EncodeToString(hash[:])[:fingerprintLength]
+}
+
+// NormalizeURL returns the URL in a canonical form, so that the same
+// resource always gets the same fingerprint: lowercase scheme and host,
+// no default port, no credentials, no fragment and only the sorted
+// names of the query parameters (values change between scans).
+// Inputs that are not absolute URLs (e.g. hostnames) are just lowercased.
+func NormalizeURL(input string) string {
+	u, err := url.Parse(input)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return strings.ToLower(input)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") &&
+		!(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	result := scheme + "://" + host + path
+
+	names := []string{}
+	for name := range u.Query() {
+		names = append(names, name)
+	}
+
+	if len(names) != 0 {
+		sort.Strings(names)
+		result += "?" + strings.Join(names, "&")
+	}
+
+	return result
+}
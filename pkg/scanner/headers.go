@@ -0,0 +1,261 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+// Header struct.
+// Name = the name that identifies the header issue.
+// HTTPHeader = the HTTP header involved.
+// Description = why the issue matters.
+type Header struct {
+	Name        string
+	HTTPHeader  string
+	Description string
+}
+
+// HeaderMatched struct.
+// Header = Header struct.
+// Host = host in which the issue is found.
+// Url = first url in which the issue is found.
+// Match = the header value (empty if the header is missing).
+type HeaderMatched struct {
+	Header Header
+	Host   string
+	URL    string
+	Match  string
+}
+
+// Header issues.
+const (
+	HeaderMissingCSP               = "Missing Content-Security-Policy"
+	HeaderCSPUnsafeInline          = "CSP allows unsafe-inline scripts"
+	HeaderCSPUnsafeEval            = "CSP allows unsafe-eval"
+	HeaderCSPWildcard              = "CSP allows scripts from any source"
+	HeaderCSPInsecureScheme        = "CSP allows scripts from insecure schemes"
+	HeaderCSPNoScriptRestriction   = "CSP does not restrict scripts"
+	HeaderCSPNoObjectRestriction   = "CSP does not restrict plugins"
+	HeaderMissingHSTS              = "Missing Strict-Transport-Security"
+	HeaderHSTSShortMaxAge          = "HSTS max-age too short"
+	HeaderHSTSNoSubdomains         = "HSTS without includeSubDomains"
+	HeaderMissingXFrameOptions     = "Missing X-Frame-Options"
+	HeaderWeakXFrameOptions        = "Weak X-Frame-Options"
+	HeaderMissingReferrerPolicy    = "Missing Referrer-Policy"
+	HeaderWeakReferrerPolicy       = "Weak Referrer-Policy"
+	HeaderMissingPermissionsPolicy = "Missing Permissions-Policy"
+	HeaderInfoLeak                 = "Information leaking header"
+	HeaderDebug                    = "Debug header"
+)
+
+// GetHeaderIssues returns all the header structs.
+func GetHeaderIssues() []Header {
+	var issues = []Header{
+		{
+			HeaderMissingCSP,
+			"Content-Security-Policy",
+			"No Content Security Policy is defined, XSS attacks are not mitigated.",
+		},
+		{
+			HeaderCSPUnsafeInline,
+			"Content-Security-Policy",
+			"Inline scripts are allowed, XSS attacks are not mitigated.",
+		},
+		{
+			HeaderCSPUnsafeEval,
+			"Content-Security-Policy",
+			"eval() and similar functions are allowed.",
+		},
+		{
+			HeaderCSPWildcard,
+			"Content-Security-Policy",
+			"Scripts can be loaded from any host.",
+		},
+		{
+			HeaderCSPInsecureScheme,
+			"Content-Security-Policy",
+			"Scripts can be loaded using http:, data: or blob: sources.",
+		},
+		{
+			HeaderCSPNoScriptRestriction,
+			"Content-Security-Policy",
+			"Neither script-src nor default-src are defined.",
+		},
+		{
+			HeaderCSPNoObjectRestriction,
+			"Content-Security-Policy",
+			"Neither object-src nor default-src are defined.",
+		},
+		{
+			HeaderMissingHSTS,
+			"Strict-Transport-Security",
+			"HTTPS is not enforced, connections can be downgraded.",
+		},
+		{
+			HeaderHSTSShortMaxAge,
+			"Strict-Transport-Security",
+			"The HSTS max-age is lower than 180 days.",
+		},
+		{
+			HeaderHSTSNoSubdomains,
+			"Strict-Transport-Security",
+			"HTTPS is not enforced on subdomains.",
+		},
+		{
+			HeaderMissingXFrameOptions,
+			"X-Frame-Options",
+			"Neither X-Frame-Options nor CSP frame-ancestors are defined, clickjacking is not mitigated.",
+		},
+		{
+			HeaderWeakXFrameOptions,
+			"X-Frame-Options",
+			"The X-Frame-Options value is not DENY or SAMEORIGIN.",
+		},
+		{
+			HeaderMissingReferrerPolicy,
+			"Referrer-Policy",
+			"No Referrer-Policy is defined.",
+		},
+		{
+			HeaderWeakReferrerPolicy,
+			"Referrer-Policy",
+			"Full URLs can be leaked to other origins.",
+		},
+		{
+			HeaderMissingPermissionsPolicy,
+			"Permissions-Policy",
+			"No Permissions-Policy is defined.",
+		},
+		{
+			HeaderInfoLeak,
+			"",
+			"The header discloses software or version information.",
+		},
+		{
+			HeaderDebug,
+			"",
+			"The header discloses debug information.",
+		},
+	}
+
+	return issues
+}
+
+// GetHeaderIssue returns the header struct identified by name.
+func GetHeaderIssue(name string) Header {
+	for _, issue := range GetHeaderIssues() {
+		if issue.Name == name {
+			return issue
+		}
+	}
+
+	return Header{Name: name}
+}
+
+// GetLeakingHeaders returns the headers disclosing
+// software or version information.
+func GetLeakingHeaders() []string {
+	return []string{
+		"Server",
+		"X-Powered-By",
+		"X-AspNet-Version",
+		"X-AspNetMvc-Version",
+		"X-Generator",
+		"X-Runtime",
+		"X-Version",
+		"X-Backend-Server",
+		"X-Server",
+		"Via",
+	}
+}
+
+// GetDebugHeaders returns the headers disclosing debug information.
+func GetDebugHeaders() []string {
+	return []string{
+		"X-Debug",
+		"X-Debug-Token",
+		"X-Debug-Token-Link",
+		"X-Debug-Info",
+		"X-Sourcemap",
+		"SourceMap",
+		"X-Trace",
+		"X-Exception",
+		"X-Error",
+		"X-ChromeLogger-Data",
+		"X-Php-Ob-Level",
+	}
+}
+
+// RemoveDuplicateHeaders removes duplicates from Headers found.
+// Header issues are deduplicated per host.
+func RemoveDuplicateHeaders(input []HeaderMatched) []HeaderMatched {
+	keys := make(map[string]bool)
+	list := []HeaderMatched{}
+
+	for _, entry := range input {
+		key := entry.Host + entry.Header.Name + entry.Header.HTTPHeader
+		if _, value := keys[key]; !value {
+			keys[key] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// GetHeaderSeverities returns the severity of the header issues
+// more severe than info.
+func GetHeaderSeverities() map[string]string {
+	return map[string]string{
+		HeaderMissingCSP:             SeverityLow,
+		HeaderCSPUnsafeInline:        SeverityLow,
+		HeaderCSPUnsafeEval:          SeverityLow,
+		HeaderCSPWildcard:            SeverityMedium,
+		HeaderCSPInsecureScheme:      SeverityMedium,
+		HeaderCSPNoScriptRestriction: SeverityLow,
+		HeaderMissingHSTS:            SeverityLow,
+		HeaderMissingXFrameOptions:   SeverityLow,
+		HeaderDebug:                  SeverityLow,
+	}
+}
+
+// Severity returns the severity of the header issue found.
+func (h HeaderMatched) Severity() string {
+	if severity, ok := GetHeaderSeverities()[h.Header.Name]; ok {
+		return severity
+	}
+
+	return SeverityInfo
+}
+
+// Confidence returns the confidence of the header issue found
+// (the headers are always read from the response).
+func (h HeaderMatched) Confidence() string {
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the header issue found.
+func (h HeaderMatched) Fingerprint() string {
+	return FindingFingerprint("header:"+h.Header.Name, h.Host, h.Match)
+}
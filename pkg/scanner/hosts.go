@@ -0,0 +1,119 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import "sort"
+
+// Host struct.
+// Name = the hostname.
+// Scope = the relation with the target (subdomain, same root, third party).
+// Source = where the hostname is found (link, body, csp...).
+type Host struct {
+	Name   string `json:"name"`
+	Scope  string `json:"scope"`
+	Source string `json:"source"`
+}
+
+// HostMatched struct.
+// Host = Host struct.
+// Url = first url in which the hostname is found.
+type HostMatched struct {
+	Host Host
+	URL  string
+}
+
+// Host scopes.
+const (
+	HostScopeSubdomain  = "subdomain"
+	HostScopeSameRoot   = "same root"
+	HostScopeThirdParty = "third party"
+)
+
+// Host sources.
+const (
+	HostSourceLink        = "link"
+	HostSourceBody        = "body"
+	HostSourceJavaScript  = "javascript"
+	HostSourceCSP         = "csp"
+	HostSourceCORS        = "cors"
+	HostSourceCertificate = "certificate"
+)
+
+// GetHostScopes returns the host scopes, from the
+// closest to the farthest from the target.
+func GetHostScopes() []string {
+	return []string{HostScopeSubdomain, HostScopeSameRoot, HostScopeThirdParty}
+}
+
+// RemoveDuplicateHosts removes duplicates from Hosts found.
+// The result is sorted by scope and name.
+func RemoveDuplicateHosts(input []HostMatched) []HostMatched {
+	keys := make(map[string]bool)
+	list := []HostMatched{}
+
+	for _, entry := range input {
+		if _, value := keys[entry.Host.Name]; !value {
+			keys[entry.Host.Name] = true
+			list = append(list, entry)
+		}
+	}
+
+	rank := make(map[string]int)
+	for i, scope := range GetHostScopes() {
+		rank[scope] = i
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Host.Scope != list[j].Host.Scope {
+			return rank[list[i].Host.Scope] < rank[list[j].Host.Scope]
+		}
+
+		return list[i].Host.Name < list[j].Host.Name
+	})
+
+	return list
+}
+
+// Severity returns the severity of the host found.
+func (h HostMatched) Severity() string {
+	return SeverityInfo
+}
+
+// Confidence returns the confidence of the host found
+// (lower for the hosts found in the body text).
+func (h HostMatched) Confidence() string {
+	if h.Host.Source == HostSourceBody {
+		return ConfidenceMedium
+	}
+
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the host found.
+func (h HostMatched) Fingerprint() string {
+	return FindingFingerprint("host", h.Host.Name, h.Host.Name)
+}
@@ -35,10 +35,12 @@ type Info struct {
 // Info = Info struct.
 // Url = url in which the information is found.
 // Match = the string matching the regex.
+// Region = the position of the match in the response body (if known).
 type InfoMatched struct {
-	Info  Info
-	URL   string
-	Match string
+	Info   Info
+	URL    string
+	Match  string
+	Region *Region
 }
 
 // GetInfoRegexes returns all the info structs.
@@ -69,7 +71,7 @@ func GetInfoRegexes() []Info {
 			},
 		*/
 		/*
-			HOW TO AVOID VERY VERY LONG BASE64 IMAGES ???
+			Base64-encoded content is decoded and hunted using the -decode flag.
 			{
 				"Base64-encoded JSON",
 				`ey(A|B)[A-Za-z0-9+\/]{20,}(={0,2})`,
@@ -94,3 +96,44 @@ func RemoveDuplicateInfos(input []InfoMatched) []InfoMatched {
 
 	return list
 }
+
+// GetInfoRatings returns the rating of the infos whose severity or
+// confidence differs from the default one (info severity, medium confidence).
+func GetInfoRatings() map[string]Rating {
+	return map[string]Rating{
+		"Email address":       {SeverityInfo, ConfidenceHigh},
+		"HTML comment":        {SeverityInfo, ConfidenceHigh},
+		"Internal IP address": {SeverityLow, ConfidenceMedium},
+		"IPv4 address":        {SeverityInfo, ConfidenceLow},
+		MetadataAuthor:        {SeverityInfo, ConfidenceHigh},
+		MetadataUsername:      {SeverityLow, ConfidenceHigh},
+		MetadataSoftware:      {SeverityInfo, ConfidenceHigh},
+		MetadataPath:          {SeverityLow, ConfidenceHigh},
+		MetadataPrinter:       {SeverityInfo, ConfidenceHigh},
+		MetadataCompany:       {SeverityInfo, ConfidenceHigh},
+	}
+}
+
+// infoRating returns the rating of an info.
+func infoRating(name string) Rating {
+	if rating, ok := GetInfoRatings()[name]; ok {
+		return rating
+	}
+
+	return Rating{SeverityInfo, ConfidenceMedium}
+}
+
+// Severity returns the severity of the info found.
+func (i InfoMatched) Severity() string {
+	return infoRating(i.Info.Name).Severity
+}
+
+// Confidence returns the confidence of the info found.
+func (i InfoMatched) Confidence() string {
+	return infoRating(i.Info.Name).Confidence
+}
+
+// Fingerprint returns the stable fingerprint of the info found.
+func (i InfoMatched) Fingerprint() string {
+	return FindingFingerprint("info:"+i.Info.Name, i.URL, i.Match)
+}
@@ -0,0 +1,187 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import (
+	// embed is used to embed the default vulnerabilities database.
+	_ "embed"
+	"encoding/json"
+	"errors"
+)
+
+// jsRepositoryDB is the default vulnerable JavaScript libraries
+// database, in the Retire.js format.
+//
+//go:embed jsrepository.json
+var jsRepositoryDB []byte // nolint: gochecknoglobals
+
+// ErrJSRepositoryEmpty is returned when a vulnerabilities database
+// doesn't define any library.
+var ErrJSRepositoryEmpty = errors.New("no libraries defined in the vulnerabilities database")
+
+const (
+	// JSVersionPlaceholder is the placeholder used in the
+	// extractors for the version.
+	JSVersionPlaceholder = "§§version§§"
+	// JSVersionRegex is the regex replacing the version placeholder.
+	JSVersionRegex = `[0-9][0-9a-z_\-]*(?:\.[0-9][0-9a-z_\-]*)*`
+)
+
+// JSIdentifiers struct.
+// CVE = the CVE identifiers.
+// GHSA = the GitHub advisory identifier.
+// Summary = a short description of the vulnerability.
+type JSIdentifiers struct {
+	CVE     []string `json:"CVE,omitempty"`
+	GHSA    string   `json:"githubID,omitempty"`
+	Summary string   `json:"summary,omitempty"`
+}
+
+// JSVulnerability struct.
+// A version is vulnerable if it's at or above AtOrAbove (if defined)
+// and below Below.
+type JSVulnerability struct {
+	AtOrAbove   string        `json:"atOrAbove,omitempty"`
+	Below       string        `json:"below"`
+	Severity    string        `json:"severity"`
+	Identifiers JSIdentifiers `json:"identifiers"`
+	Info        []string      `json:"info,omitempty"`
+}
+
+// IDs returns the identifiers of the vulnerability
+// (CVEs, or the GitHub advisory, or the summary).
+func (v JSVulnerability) IDs() []string {
+	switch {
+	case len(v.Identifiers.CVE) != 0:
+		return v.Identifiers.CVE
+	case v.Identifiers.GHSA != "":
+		return []string{v.Identifiers.GHSA}
+	default:
+		return []string{v.Identifiers.Summary}
+	}
+}
+
+// JSExtractors struct.
+// Regexes extracting the version of a library from
+// the URL, the file name and the file content.
+type JSExtractors struct {
+	URI         []string `json:"uri"`
+	Filename    []string `json:"filename"`
+	FileContent []string `json:"filecontent"`
+}
+
+// JSRepositoryEntry struct.
+// It describes a library in the vulnerabilities database.
+type JSRepositoryEntry struct {
+	Vulnerabilities []JSVulnerability `json:"vulnerabilities"`
+	Extractors      JSExtractors      `json:"extractors"`
+}
+
+// JSRepository is a Retire.js-compatible vulnerabilities
+// database (library name -> entry).
+type JSRepository map[string]JSRepositoryEntry
+
+// JSLibrary struct.
+// Name = the name of the library.
+// Version = the version detected.
+// Severity = the highest severity of the vulnerabilities.
+// Vulnerabilities = the known vulnerabilities of the version.
+type JSLibrary struct {
+	Name            string            `json:"name"`
+	Version         string            `json:"version"`
+	Severity        string            `json:"severity"`
+	Vulnerabilities []JSVulnerability `json:"vulnerabilities"`
+}
+
+// JSLibraryMatched struct.
+// JSLibrary = JSLibrary struct.
+// Url = url of the library.
+// Match = the string from which the version is extracted.
+type JSLibraryMatched struct {
+	JSLibrary JSLibrary
+	URL       string
+	Match     string
+}
+
+// ParseJSRepository parses a Retire.js-compatible
+// vulnerabilities database.
+func ParseJSRepository(data []byte) (JSRepository, error) {
+	repository := JSRepository{}
+	if err := json.Unmarshal(data, &repository); err != nil {
+		return nil, err
+	}
+
+	if len(repository) == 0 {
+		return nil, ErrJSRepositoryEmpty
+	}
+
+	return repository, nil
+}
+
+// GetJSRepository returns the default vulnerabilities database.
+func GetJSRepository() JSRepository {
+	repository, err := ParseJSRepository(jsRepositoryDB)
+	if err != nil {
+		panic(err)
+	}
+
+	return repository
+}
+
+// RemoveDuplicateJSLibraries removes duplicates from vulnerable
+// JavaScript libraries found.
+func RemoveDuplicateJSLibraries(input []JSLibraryMatched) []JSLibraryMatched {
+	keys := make(map[string]bool)
+	list := []JSLibraryMatched{}
+
+	for _, entry := range input {
+		key := entry.URL + entry.JSLibrary.Name + entry.JSLibrary.Version
+		if _, value := keys[key]; !value {
+			keys[key] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// Severity returns the severity of the JavaScript library found
+// (the highest severity of its vulnerabilities).
+func (j JSLibraryMatched) Severity() string {
+	return MaxSeverity(j.JSLibrary.Severity)
+}
+
+// Confidence returns the confidence of the JavaScript library
+// found (the version is always known).
+func (j JSLibraryMatched) Confidence() string {
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the JavaScript library found.
+func (j JSLibraryMatched) Fingerprint() string {
+	return FindingFingerprint("jslibrary:"+j.JSLibrary.Name, j.URL, j.JSLibrary.Version)
+}
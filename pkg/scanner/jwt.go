@@ -0,0 +1,131 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+// JWT struct.
+// Algorithm = the signing algorithm (alg header).
+// Type = the token type (typ header).
+// KeyID = the key identifier (kid header).
+// Issuer, Subject, Audience = the registered claims.
+// ExpiresAt, IssuedAt = the registered time claims (Unix time).
+// Claims = all the claims of the token.
+// Issues = a list of weaknesses found in the token.
+type JWT struct {
+	Algorithm string                 `json:"alg,omitempty"`
+	Type      string                 `json:"typ,omitempty"`
+	KeyID     string                 `json:"kid,omitempty"`
+	Issuer    string                 `json:"iss,omitempty"`
+	Subject   string                 `json:"sub,omitempty"`
+	Audience  string                 `json:"aud,omitempty"`
+	ExpiresAt int64                  `json:"exp,omitempty"`
+	IssuedAt  int64                  `json:"iat,omitempty"`
+	Claims    map[string]interface{} `json:"claims,omitempty"`
+	Issues    []string               `json:"issues,omitempty"`
+}
+
+// JWTMatched struct.
+// JWT = the decoded JWT (struct).
+// URL = url in which the token is found.
+// Source = where the token is found (body, query, cookie).
+// Match = the encoded token.
+// Encoded = the encoded blob containing the token (decoded content only).
+type JWTMatched struct {
+	JWT     JWT
+	URL     string
+	Source  string
+	Match   string
+	Encoded string
+	// fingerprint is kept when the match is redacted.
+	fingerprint string
+}
+
+// JWT issues.
+const (
+	JWTAlgNone      = "Unsigned token (alg none)"
+	JWTExpired      = "Expired token"
+	JWTNoExpiration = "Token without expiration"
+	JWTLongLived    = "Long-lived token (more than one year)"
+)
+
+// JWT sources.
+const (
+	SourceBody   = "body"
+	SourceQuery  = "query"
+	SourceCookie = "cookie"
+)
+
+// RemoveDuplicateJWTs removes duplicates from JWTs found.
+func RemoveDuplicateJWTs(input []JWTMatched) []JWTMatched {
+	keys := make(map[string]bool)
+	list := []JWTMatched{}
+
+	for _, entry := range input {
+		if _, value := keys[entry.Match]; !value {
+			keys[entry.Match] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// GetJWTIssueSeverities returns the severity of the JWT weaknesses.
+func GetJWTIssueSeverities() map[string]string {
+	return map[string]string{
+		JWTAlgNone:      SeverityHigh,
+		JWTLongLived:    SeverityLow,
+		JWTNoExpiration: SeverityLow,
+		JWTExpired:      SeverityInfo,
+	}
+}
+
+// Severity returns the severity of the JWT found
+// (the highest severity of its weaknesses).
+func (j JWTMatched) Severity() string {
+	severities := []string{}
+	for _, issue := range j.JWT.Issues {
+		severities = append(severities, GetJWTIssueSeverities()[issue])
+	}
+
+	return MaxSeverity(severities...)
+}
+
+// Confidence returns the confidence of the JWT found
+// (the token is always decoded).
+func (j JWTMatched) Confidence() string {
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the JWT found.
+// The fingerprint of a redacted token is the one of the original match.
+func (j JWTMatched) Fingerprint() string {
+	if j.fingerprint != "" {
+		return j.fingerprint
+	}
+
+	return FindingFingerprint("jwt:"+j.Source, j.URL, j.Match)
+}
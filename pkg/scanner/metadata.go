@@ -0,0 +1,90 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+// Metadata struct.
+// Name = the name of the information (Document author ...).
+// Value = the value extracted from the document.
+type Metadata struct {
+	Name  string
+	Value string
+}
+
+// Document struct.
+// Format = the format of the document (pdf, ooxml).
+// Metadata = the metadata extracted.
+// Text = the text extracted (metadata values and content).
+type Document struct {
+	Format   string
+	Metadata []Metadata
+	Text     string
+}
+
+const (
+	DocumentPDF   = "pdf"
+	DocumentOOXML = "ooxml"
+
+	MetadataAuthor   = "Document author"
+	MetadataUsername = "Document username"
+	MetadataSoftware = "Document software"
+	MetadataPath     = "Document internal path"
+	MetadataPrinter  = "Document printer"
+	MetadataCompany  = "Document company"
+)
+
+// GetPDFInfoKeys returns the keys of the PDF info dictionary
+// extracted and the metadata they are reported as.
+func GetPDFInfoKeys() map[string]string {
+	return map[string]string{
+		"Author":   MetadataAuthor,
+		"Creator":  MetadataSoftware,
+		"Producer": MetadataSoftware,
+		"Company":  MetadataCompany,
+	}
+}
+
+// GetXMPTags returns the XMP tags extracted
+// and the metadata they are reported as.
+func GetXMPTags() map[string]string {
+	return map[string]string{
+		"dc:creator":      MetadataAuthor,
+		"pdf:Author":      MetadataAuthor,
+		"xmp:CreatorTool": MetadataSoftware,
+		"pdf:Producer":    MetadataSoftware,
+	}
+}
+
+// GetOOXMLProperties returns the document properties (docProps/core.xml
+// and docProps/app.xml) extracted and the metadata they are reported as.
+func GetOOXMLProperties() map[string]string {
+	return map[string]string{
+		"dc:creator":        MetadataAuthor,
+		"cp:lastModifiedBy": MetadataAuthor,
+		"Company":           MetadataCompany,
+		"Manager":           MetadataAuthor,
+	}
+}
@@ -0,0 +1,174 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// redactVisibleChars is the maximum number of characters
+	// left visible at the beginning and at the end of a secret.
+	redactVisibleChars = 4
+	// redactVisibleRatio is the fraction (1/n) of the secret
+	// length that can be left visible on each side.
+	redactVisibleRatio = 4
+	// fingerprintLength is the number of hex characters
+	// kept in a secret fingerprint.
+	fingerprintLength = 16
+)
+
+// RedactSecret masks all the characters of a secret except
+// a short prefix and suffix.
+// If fingerprint is true a hashed fingerprint of the
+// whole secret is appended to the result, in this way the same
+// secret can be correlated across different scans.
+func RedactSecret(secret string, fingerprint bool) string {
+	runes := []rune(secret)
+
+	visible := len(runes) / redactVisibleRatio
+	if visible > redactVisibleChars {
+		visible = redactVisibleChars
+	}
+
+	redacted := string(runes[:visible]) +
+		strings.Repeat("*", len(runes)-2*visible) +
+		string(runes[len(runes)-visible:])
+
+	if fingerprint {
+		redacted += " [" + SecretFingerprint(secret) + "]"
+	}
+
+	return redacted
+}
+
+// SecretFingerprint returns a stable hashed fingerprint of a secret.
+func SecretFingerprint(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return "sha256:" + hex.EncodeToString(hash[:])[:fingerprintLength]
+}
+
+// RedactSecrets returns a copy of the secrets found
+// with all the matches redacted.
+func RedactSecrets(input []SecretMatched, fingerprint bool) []SecretMatched {
+	list := make([]SecretMatched, 0, len(input))
+
+	for _, entry := range input {
+		entry.fingerprint = entry.Fingerprint()
+		entry.Match = RedactSecret(entry.Match, fingerprint)
+		list = append(list, entry)
+	}
+
+	return list
+}
+
+// RedactJWTs returns a copy of the JWTs found
+// with all the tokens redacted.
+func RedactJWTs(input []JWTMatched, fingerprint bool) []JWTMatched {
+	list := make([]JWTMatched, 0, len(input))
+
+	for _, entry := range input {
+		entry.fingerprint = entry.Fingerprint()
+		entry.Match = RedactSecret(entry.Match, fingerprint)
+		list = append(list, entry)
+	}
+
+	return list
+}
+
+// RedactCookies returns a copy of the cookies found
+// with all the values redacted (name and attributes are kept),
+// decoded values (JWT claims, serialized objects) included.
+func RedactCookies(input []CookieMatched, fingerprint bool) []CookieMatched {
+	list := make([]CookieMatched, 0, len(input))
+
+	for _, entry := range input {
+		if start, end, ok := cookieValue(entry.Match); ok {
+			entry.Match = entry.Match[:start] + RedactSecret(entry.Match[start:end], fingerprint) + entry.Match[end:]
+		}
+
+		if entry.Cookie.Decoded != "" {
+			entry.Cookie.Decoded = RedactSecret(entry.Cookie.Decoded, fingerprint)
+		}
+
+		list = append(list, entry)
+	}
+
+	return list
+}
+
+// cookieValue returns the bounds of the value in a
+// Set-Cookie header value (name=value; attributes).
+func cookieValue(raw string) (int, int, bool) {
+	end := strings.Index(raw, ";")
+	if end == -1 {
+		end = len(raw)
+	}
+
+	equal := strings.Index(raw[:end], "=")
+	if equal == -1 || equal+1 == end {
+		return 0, 0, false
+	}
+
+	return equal + 1, end, true
+}
+
+// SecretsReplacer returns a Replacer masking all the secrets,
+// the JWTs and the cookie values found in a text, together
+// with the encoded blobs they are decoded from.
+// The cookie values are masked only after their name (name=value),
+// in this way short values are not masked elsewhere.
+func SecretsReplacer(results *Results, fingerprint bool) *strings.Replacer {
+	matches := []string{}
+
+	for _, entry := range results.Secrets {
+		matches = append(matches, entry.Match, entry.Encoded)
+	}
+
+	for _, entry := range results.JWTs {
+		matches = append(matches, entry.Match, entry.Encoded)
+	}
+
+	pairs := make([]string, 0, 2*(len(matches)+len(results.Cookies)))
+
+	for _, match := range matches {
+		if match != "" {
+			pairs = append(pairs, match, RedactSecret(match, fingerprint))
+		}
+	}
+
+	for _, entry := range results.Cookies {
+		if start, end, ok := cookieValue(entry.Match); ok {
+			pairs = append(pairs, entry.Match[:end],
+				entry.Match[:start]+RedactSecret(entry.Match[start:end], fingerprint))
+		}
+	}
+
+	return strings.NewReplacer(pairs...)
+}
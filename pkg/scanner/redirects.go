@@ -0,0 +1,102 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+// Redirect struct.
+// Parameter = the parameter the controlled URL has been injected in.
+// Payload = the value injected.
+// Method = how the redirect is performed (Location header, meta refresh, JavaScript).
+// Location = the redirect target returned.
+// Severity = the severity of the open redirect.
+type Redirect struct {
+	Parameter string `json:"parameter"`
+	Payload   string `json:"payload"`
+	Method    string `json:"method"`
+	Location  string `json:"location"`
+	Severity  string `json:"severity"`
+}
+
+// RedirectMatched struct.
+// Redirect = Redirect struct.
+// Url = url of the endpoint.
+type RedirectMatched struct {
+	Redirect Redirect
+	URL      string
+}
+
+// Redirect methods.
+const (
+	RedirectHeader     = "Location header"
+	RedirectMeta       = "meta refresh"
+	RedirectJavaScript = "JavaScript"
+)
+
+// RedirectDefaultURL is the controlled external URL
+// injected when no custom one is defined.
+const RedirectDefaultURL = "https://" + CORSAttackerDomain + "/"
+
+// GetRedirectPayloads returns the values injected in the redirect-like
+// parameters: the controlled URL and its scheme-relative version.
+func GetRedirectPayloads(controlled, host string) []string {
+	return []string{controlled, "//" + host + "/"}
+}
+
+// RemoveDuplicateRedirects removes duplicates from open redirects found.
+func RemoveDuplicateRedirects(input []RedirectMatched) []RedirectMatched {
+	keys := make(map[string]bool)
+	list := []RedirectMatched{}
+
+	for _, entry := range input {
+		key := entry.URL + entry.Redirect.Parameter
+		if _, value := keys[key]; !value {
+			keys[key] = true
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// Severity returns the severity of the open redirect found.
+func (r RedirectMatched) Severity() string {
+	return r.Redirect.Severity
+}
+
+// Confidence returns the confidence of the open redirect found
+// (JavaScript redirects could not be executed).
+func (r RedirectMatched) Confidence() string {
+	if r.Redirect.Method == RedirectJavaScript {
+		return ConfidenceMedium
+	}
+
+	return ConfidenceHigh
+}
+
+// Fingerprint returns the stable fingerprint of the open redirect found.
+func (r RedirectMatched) Fingerprint() string {
+	return FindingFingerprint("redirect:"+r.Redirect.Parameter, r.URL, r.Redirect.Method)
+}
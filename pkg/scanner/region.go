@@ -0,0 +1,57 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Region struct.
+// It's the position of a match in the response body.
+// Offset, Length = the offset and the length of the match (in characters).
+// Line, Column = the line and the column (1-based) in which the match starts.
+type Region struct {
+	Offset int
+	Length int
+	Line   int
+	Column int
+}
+
+// NewRegion returns the region of the match text[start:end]
+// (start and end are byte indexes, as returned by the regexes).
+func NewRegion(text string, start, end int) *Region {
+	before := text[:start]
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+
+	return &Region{
+		Offset: utf8.RuneCountInString(before),
+		Length: utf8.RuneCountInString(text[start:end]),
+		Line:   strings.Count(before, "\n") + 1,
+		Column: utf8.RuneCountInString(before[lineStart:]) + 1,
+	}
+}
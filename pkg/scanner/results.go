@@ -0,0 +1,210 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import (
+	"sort"
+	"time"
+)
+
+// Results struct.
+// It contains all the results found while crawling.
+type Results struct {
+	URLs         []string
+	Secrets      []SecretMatched
+	Endpoints    []EndpointMatched
+	Extensions   []FileTypeMatched
+	Errors       []ErrorMatched
+	Infos        []InfoMatched
+	JWTs         []JWTMatched
+	Headers      []HeaderMatched
+	Cookies      []CookieMatched
+	CORS         []CORSMatched
+	Technologies []TechnologyMatched
+	JSLibraries  []JSLibraryMatched
+	Hosts        []HostMatched
+	Buckets      []BucketMatched
+	APIs         []APIMatched
+	Reflections  []ReflectionMatched
+	Redirects    []RedirectMatched
+	Exposures    []ExposureMatched
+}
+
+// Append appends all the results of other to r.
+func (r *Results) Append(other *Results) {
+	r.URLs = append(r.URLs, other.URLs...)
+	r.Secrets = append(r.Secrets, other.Secrets...)
+	r.Endpoints = append(r.Endpoints, other.Endpoints...)
+	r.Extensions = append(r.Extensions, other.Extensions...)
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Infos = append(r.Infos, other.Infos...)
+	r.JWTs = append(r.JWTs, other.JWTs...)
+	r.Headers = append(r.Headers, other.Headers...)
+	r.Cookies = append(r.Cookies, other.Cookies...)
+	r.CORS = append(r.CORS, other.CORS...)
+	r.Technologies = append(r.Technologies, other.Technologies...)
+	r.JSLibraries = append(r.JSLibraries, other.JSLibraries...)
+	r.Hosts = append(r.Hosts, other.Hosts...)
+	r.Buckets = append(r.Buckets, other.Buckets...)
+	r.APIs = append(r.APIs, other.APIs...)
+	r.Reflections = append(r.Reflections, other.Reflections...)
+	r.Redirects = append(r.Redirects, other.Redirects...)
+	r.Exposures = append(r.Exposures, other.Exposures...)
+}
+
+// Filter removes the findings whose severity is below the minimum one.
+// The parameters and the paths of the endpoints are filtered one by one.
+func (r *Results) Filter(minimum string) {
+	if minimum == "" {
+		return
+	}
+
+	endpoints := []EndpointMatched{}
+
+	for _, endpoint := range r.Endpoints {
+		parameters := []Parameter{}
+		paths := []JuicyPath{}
+
+		for _, parameter := range endpoint.Parameters {
+			if AtLeast(ParameterSeverity(parameter), minimum) {
+				parameters = append(parameters, parameter)
+			}
+		}
+
+		for _, path := range endpoint.Paths {
+			if AtLeast(path.Severity, minimum) {
+				paths = append(paths, path)
+			}
+		}
+
+		if len(parameters) != 0 || len(paths) != 0 {
+			endpoints = append(endpoints, EndpointMatched{Parameters: parameters, Paths: paths, URL: endpoint.URL})
+		}
+	}
+
+	r.Endpoints = endpoints
+	r.Secrets = FilterFindings(r.Secrets, minimum)
+	r.Extensions = FilterFindings(r.Extensions, minimum)
+	r.Errors = FilterFindings(r.Errors, minimum)
+	r.Infos = FilterFindings(r.Infos, minimum)
+	r.JWTs = FilterFindings(r.JWTs, minimum)
+	r.Headers = FilterFindings(r.Headers, minimum)
+	r.Cookies = FilterFindings(r.Cookies, minimum)
+	r.CORS = FilterFindings(r.CORS, minimum)
+	r.Technologies = FilterFindings(r.Technologies, minimum)
+	r.JSLibraries = FilterFindings(r.JSLibraries, minimum)
+	r.Hosts = FilterFindings(r.Hosts, minimum)
+	r.Buckets = FilterFindings(r.Buckets, minimum)
+	r.APIs = FilterFindings(r.APIs, minimum)
+	r.Reflections = FilterFindings(r.Reflections, minimum)
+	r.Redirects = FilterFindings(r.Redirects, minimum)
+	r.Exposures = FilterFindings(r.Exposures, minimum)
+}
+
+// Suppress removes the findings that are in the baseline (and not expired).
+// The parameters and the paths of the endpoints are suppressed one by one.
+func (r *Results) Suppress(baseline *Baseline, now time.Time) {
+	if baseline == nil {
+		return
+	}
+
+	endpoints := []EndpointMatched{}
+
+	for _, endpoint := range r.Endpoints {
+		parameters := []Parameter{}
+		paths := []JuicyPath{}
+
+		for _, parameter := range endpoint.Parameters {
+			if !baseline.Suppresses(ParameterFingerprint(endpoint.URL, parameter), now) {
+				parameters = append(parameters, parameter)
+			}
+		}
+
+		for _, path := range endpoint.Paths {
+			if !baseline.Suppresses(PathFingerprint(endpoint.URL, path), now) {
+				paths = append(paths, path)
+			}
+		}
+
+		if len(parameters) != 0 || len(paths) != 0 {
+			endpoints = append(endpoints, EndpointMatched{Parameters: parameters, Paths: paths, URL: endpoint.URL})
+		}
+	}
+
+	r.Endpoints = endpoints
+	r.Secrets = SuppressFindings(r.Secrets, baseline, now)
+	r.Extensions = SuppressFindings(r.Extensions, baseline, now)
+	r.Errors = SuppressFindings(r.Errors, baseline, now)
+	r.Infos = SuppressFindings(r.Infos, baseline, now)
+	r.JWTs = SuppressFindings(r.JWTs, baseline, now)
+	r.Headers = SuppressFindings(r.Headers, baseline, now)
+	r.Cookies = SuppressFindings(r.Cookies, baseline, now)
+	r.CORS = SuppressFindings(r.CORS, baseline, now)
+	r.Technologies = SuppressFindings(r.Technologies, baseline, now)
+	r.JSLibraries = SuppressFindings(r.JSLibraries, baseline, now)
+	r.Hosts = SuppressFindings(r.Hosts, baseline, now)
+	r.Buckets = SuppressFindings(r.Buckets, baseline, now)
+	r.APIs = SuppressFindings(r.APIs, baseline, now)
+	r.Reflections = SuppressFindings(r.Reflections, baseline, now)
+	r.Redirects = SuppressFindings(r.Redirects, baseline, now)
+	r.Exposures = SuppressFindings(r.Exposures, baseline, now)
+}
+
+// Sort sorts the findings by severity and confidence, the highest first.
+// The parameters and the paths of the endpoints are sorted too.
+func (r *Results) Sort() {
+	for _, endpoint := range r.Endpoints {
+		parameters := endpoint.Parameters
+		sort.SliceStable(parameters, func(i, j int) bool {
+			return Outranks(ParameterSeverity(parameters[i]), parameters[i].Confidence,
+				ParameterSeverity(parameters[j]), parameters[j].Confidence)
+		})
+
+		paths := endpoint.Paths
+		sort.SliceStable(paths, func(i, j int) bool {
+			return SeverityRank(paths[i].Severity) > SeverityRank(paths[j].Severity)
+		})
+	}
+
+	SortFindings(r.Endpoints)
+	SortFindings(r.Secrets)
+	SortFindings(r.Extensions)
+	SortFindings(r.Errors)
+	SortFindings(r.Infos)
+	SortFindings(r.JWTs)
+	SortFindings(r.Headers)
+	SortFindings(r.Cookies)
+	SortFindings(r.CORS)
+	SortFindings(r.Technologies)
+	SortFindings(r.JSLibraries)
+	SortFindings(r.Hosts)
+	SortFindings(r.Buckets)
+	SortFindings(r.APIs)
+	SortFindings(r.Reflections)
+	SortFindings(r.Redirects)
+	SortFindings(r.Exposures)
+}
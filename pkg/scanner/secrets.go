@@ -44,10 +44,16 @@ type Secret struct {
 // Secret = The secret matched (struct).
 // Url = url in which is present the secret.
 // Match = the string matching the regex.
+// Encoded = the encoded blob containing the secret (decoded content only).
+// Region = the position of the match in the response body (if known).
 type SecretMatched struct {
-	Secret Secret
-	URL    string
-	Match  string
+	Secret  Secret
+	URL     string
+	Match   string
+	Encoded string
+	Region  *Region
+	// fingerprint is kept when the match is redacted.
+	fingerprint string
 }
 
 // GetSecretRegexes returns a slice of all
@@ -391,3 +397,70 @@ func RemoveDuplicateSecrets(input []SecretMatched) []SecretMatched {
 
 	return list
 }
+
+// GetSecretRatings returns the rating of the secrets whose severity or
+// confidence differs from the default one (high severity, high confidence):
+// identifiers are less severe than keys, generic patterns less reliable.
+func GetSecretRatings() map[string]Rating {
+	return map[string]Rating{
+		"AWS Secret Key":               {SeverityCritical, ConfidenceMedium},
+		"Asymmetric Private Key":       {SeverityCritical, ConfidenceHigh},
+		"Google (GCP) Service Account": {SeverityCritical, ConfidenceHigh},
+		"Github Personal Access Token": {SeverityCritical, ConfidenceHigh},
+		"Github OAuth Access Token":    {SeverityCritical, ConfidenceHigh},
+		"Github App Token":             {SeverityCritical, ConfidenceHigh},
+		"Github Refresh Token":         {SeverityCritical, ConfidenceHigh},
+		"Stripe API key":               {SeverityCritical, ConfidenceHigh},
+		"PyPI upload token":            {SeverityCritical, ConfidenceHigh},
+		"Square OAuth secret":          {SeverityCritical, ConfidenceHigh},
+		"Facebook Secret Key":          {SeverityHigh, ConfidenceMedium},
+		"Twitter Secret Key":           {SeverityHigh, ConfidenceMedium},
+		"LinkedIn Secret Key":          {SeverityHigh, ConfidenceMedium},
+		"Heroku API key":               {SeverityHigh, ConfidenceLow},
+		"CustomFromFile":               {SeverityHigh, ConfidenceMedium},
+		"Google API key":               {SeverityMedium, ConfidenceHigh},
+		"Mapbox Token Disclosure":      {SeverityMedium, ConfidenceHigh},
+		"Slack Webhook":                {SeverityMedium, ConfidenceHigh},
+		"Discord Webhook":              {SeverityMedium, ConfidenceHigh},
+		"Microsoft Teams Webhook":      {SeverityMedium, ConfidenceHigh},
+		"Picatic API key":              {SeverityMedium, ConfidenceMedium},
+		"Bugsnag API Key":              {SeverityMedium, ConfidenceLow},
+		"Facebook Client ID":           {SeverityLow, ConfidenceMedium},
+		"Twitter Client ID":            {SeverityLow, ConfidenceMedium},
+		"LinkedIn Client ID":           {SeverityLow, ConfidenceMedium},
+		"Amazon SNS topic":             {SeverityLow, ConfidenceHigh},
+		"Firebase Database":            {SeverityLow, ConfidenceHigh},
+		"AWS cognito pool":             {SeverityLow, ConfidenceHigh},
+		"S3 Bucket":                    {SeverityLow, ConfidenceHigh},
+		"Google Calendar URI":          {SeverityLow, ConfidenceHigh},
+	}
+}
+
+// secretRating returns the rating of a secret.
+func secretRating(name string) Rating {
+	if rating, ok := GetSecretRatings()[name]; ok {
+		return rating
+	}
+
+	return Rating{SeverityHigh, ConfidenceHigh}
+}
+
+// Severity returns the severity of the secret found.
+func (s SecretMatched) Severity() string {
+	return secretRating(s.Secret.Name).Severity
+}
+
+// Confidence returns the confidence of the secret found.
+func (s SecretMatched) Confidence() string {
+	return secretRating(s.Secret.Name).Confidence
+}
+
+// Fingerprint returns the stable fingerprint of the secret found.
+// The fingerprint of a redacted secret is the one of the original match.
+func (s SecretMatched) Fingerprint() string {
+	if s.fingerprint != "" {
+		return s.fingerprint
+	}
+
+	return FindingFingerprint("secret:"+s.Secret.Name, s.URL, s.Match)
+}
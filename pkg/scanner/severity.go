@@ -0,0 +1,165 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import "sort"
+
+// Severity levels.
+const (
+	SeverityInfo     = "info"
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// GetSeverities returns the severity levels, from the
+// lowest to the highest.
+func GetSeverities() []string {
+	return []string{SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+}
+
+// SeverityRank returns the rank of a severity level
+// (0 for unknown levels, 1 for info up to 5 for critical).
+func SeverityRank(severity string) int {
+	for i, level := range GetSeverities() {
+		if level == severity {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// Confidence levels.
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// GetConfidences returns the confidence levels, from the
+// lowest to the highest.
+func GetConfidences() []string {
+	return []string{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}
+}
+
+// ConfidenceRank returns the rank of a confidence level
+// (0 for unknown levels, 1 for low up to 3 for high).
+func ConfidenceRank(confidence string) int {
+	for i, level := range GetConfidences() {
+		if level == confidence {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// Rating struct.
+// Severity = the severity of a finding (info, low, medium, high, critical).
+// Confidence = the confidence of a finding (low, medium, high).
+type Rating struct {
+	Severity   string
+	Confidence string
+}
+
+// Finding is implemented by all the finding types:
+// every finding has a severity and a confidence.
+type Finding interface {
+	Severity() string
+	Confidence() string
+}
+
+// AtLeast checks if a severity is at least the minimum one.
+// An empty minimum matches every severity.
+func AtLeast(severity, minimum string) bool {
+	return minimum == "" || SeverityRank(severity) >= SeverityRank(minimum)
+}
+
+// MaxSeverity returns the highest of the severities.
+func MaxSeverity(severities ...string) string {
+	result := SeverityInfo
+
+	for _, severity := range severities {
+		if SeverityRank(severity) > SeverityRank(result) {
+			result = severity
+		}
+	}
+
+	return result
+}
+
+// MaxConfidence returns the highest of the confidences.
+func MaxConfidence(confidences ...string) string {
+	result := ConfidenceLow
+
+	for _, confidence := range confidences {
+		if ConfidenceRank(confidence) > ConfidenceRank(result) {
+			result = confidence
+		}
+	}
+
+	return result
+}
+
+// FilterFindings returns the findings whose severity
+// is at least the minimum one.
+func FilterFindings[T Finding](findings []T, minimum string) []T {
+	if minimum == "" || findings == nil {
+		return findings
+	}
+
+	result := []T{}
+
+	for _, finding := range findings {
+		if AtLeast(finding.Severity(), minimum) {
+			result = append(result, finding)
+		}
+	}
+
+	return result
+}
+
+// SortFindings sorts the findings by severity and then by
+// confidence, the highest first. The sort is stable.
+func SortFindings[T Finding](findings []T) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return Outranks(findings[i].Severity(), findings[i].Confidence(),
+			findings[j].Severity(), findings[j].Confidence())
+	})
+}
+
+// Outranks checks if a finding (severity and confidence)
+// must be triaged before another one.
+func Outranks(severity, confidence, otherSeverity, otherConfidence string) bool {
+	if SeverityRank(severity) != SeverityRank(otherSeverity) {
+		return SeverityRank(severity) > SeverityRank(otherSeverity)
+	}
+
+	return ConfidenceRank(confidence) > ConfidenceRank(otherConfidence)
+}
@@ -0,0 +1,152 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/edoardottt/cariddi/pkg/scanner"
+)
+
+func errorMatched(name string) scanner.ErrorMatched {
+	return scanner.ErrorMatched{Error: scanner.Error{ErrorName: name}, URL: "http://example.com", Match: name}
+}
+
+func TestFilterFindings(t *testing.T) {
+	findings := []scanner.ErrorMatched{
+		errorMatched("General error"),
+		errorMatched("MySQL error"),
+		errorMatched("Debug information"),
+	}
+	tests := []struct {
+		name    string
+		minimum string
+		want    []scanner.ErrorMatched
+	}{
+		{
+			name:    "no minimum",
+			minimum: "",
+			want:    findings,
+		},
+		{
+			name:    "minimum info",
+			minimum: scanner.SeverityInfo,
+			want:    findings,
+		},
+		{
+			name:    "minimum medium",
+			minimum: scanner.SeverityMedium,
+			want:    []scanner.ErrorMatched{errorMatched("MySQL error"), errorMatched("Debug information")},
+		},
+		{
+			name:    "minimum critical",
+			minimum: scanner.SeverityCritical,
+			want:    []scanner.ErrorMatched{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanner.FilterFindings(findings, tt.minimum); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterFindings\n%v", got)
+				t.Errorf("want\n%v", tt.want)
+			}
+		})
+	}
+}
+
+func TestResultsFilterAndSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		minimum string
+		input   []scanner.Parameter
+		want    []scanner.Parameter
+	}{
+		{
+			name:    "sort only",
+			minimum: "",
+			input: []scanner.Parameter{
+				{Parameter: "page"},
+				{Parameter: "q", Attacks: []string{"XSS"}, Confidence: scanner.ConfidenceMedium},
+				{Parameter: "id", Attacks: []string{"SQLi"}, Confidence: scanner.ConfidenceHigh},
+			},
+			want: []scanner.Parameter{
+				{Parameter: "id", Attacks: []string{"SQLi"}, Confidence: scanner.ConfidenceHigh},
+				{Parameter: "q", Attacks: []string{"XSS"}, Confidence: scanner.ConfidenceMedium},
+				{Parameter: "page"},
+			},
+		},
+		{
+			name:    "minimum low",
+			minimum: scanner.SeverityLow,
+			input: []scanner.Parameter{
+				{Parameter: "page"},
+				{Parameter: "q", Attacks: []string{"XSS"}, Confidence: scanner.ConfidenceMedium},
+				{Parameter: "id", Attacks: []string{"SQLi"}, Confidence: scanner.ConfidenceHigh},
+			},
+			want: []scanner.Parameter{
+				{Parameter: "id", Attacks: []string{"SQLi"}, Confidence: scanner.ConfidenceHigh},
+				{Parameter: "q", Attacks: []string{"XSS"}, Confidence: scanner.ConfidenceMedium},
+			},
+		},
+		{
+			name:    "minimum high",
+			minimum: scanner.SeverityHigh,
+			input: []scanner.Parameter{
+				{Parameter: "page"},
+				{Parameter: "id", Attacks: []string{"SQLi"}, Confidence: scanner.ConfidenceHigh},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := &scanner.Results{
+				Endpoints: []scanner.EndpointMatched{{Parameters: tt.input, URL: "http://example.com"}},
+				Errors:    []scanner.ErrorMatched{errorMatched("General error"), errorMatched("MySQL error")},
+			}
+			results.Filter(tt.minimum)
+			results.Sort()
+
+			var got []scanner.Parameter
+			if len(results.Endpoints) != 0 {
+				got = results.Endpoints[0].Parameters
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Results.Filter\n%v", got)
+				t.Errorf("want\n%v", tt.want)
+			}
+
+			if len(results.Errors) != 0 && results.Errors[0].Error.ErrorName != "MySQL error" {
+				t.Errorf("Results.Sort\n%v", results.Errors)
+			}
+		})
+	}
+}
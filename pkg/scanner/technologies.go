@@ -0,0 +1,203 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import (
+	// embed is used to embed the default fingerprints database.
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// technologiesDB is the default fingerprints database,
+// in the Wappalyzer format.
+//
+//go:embed technologies.json
+var technologiesDB []byte // nolint: gochecknoglobals
+
+// ErrFingerprintsEmpty is returned when a fingerprints database
+// doesn't define any technology.
+var ErrFingerprintsEmpty = errors.New("no technologies defined in the fingerprints database")
+
+// Technology struct.
+// Name = the name of the technology.
+// Version = the version detected (if any).
+// Categories = the categories of the technology (CMS, Web servers...).
+// Website = the website of the technology.
+type Technology struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	Website    string   `json:"website,omitempty"`
+}
+
+// TechnologyMatched struct.
+// Technology = Technology struct.
+// Host = host running the technology.
+// Url = url in which the technology is detected.
+// Match = the evidence of the technology (header, cookie, meta tag...).
+type TechnologyMatched struct {
+	Technology Technology
+	Host       string
+	URL        string
+	Match      string
+}
+
+// Patterns is a list of Wappalyzer patterns.
+// In the database it can be either a string or an array of strings.
+type Patterns []string
+
+// UnmarshalJSON decodes both a string and an array of strings.
+func (p *Patterns) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*p = Patterns{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*p = list
+
+	return nil
+}
+
+// Fingerprint struct.
+// It describes how a technology is detected (Wappalyzer format).
+// Patterns are regular expressions optionally followed by
+// tags (e.g. `nginx(?:/([\d.]+))?\;version:\1`), an empty pattern
+// only checks the presence of the header/cookie/meta tag.
+type Fingerprint struct {
+	Cats      []int               `json:"cats"`
+	Headers   map[string]Patterns `json:"headers"`
+	Cookies   map[string]Patterns `json:"cookies"`
+	Meta      map[string]Patterns `json:"meta"`
+	HTML      Patterns            `json:"html"`
+	ScriptSrc Patterns            `json:"scriptSrc"`
+	Scripts   Patterns            `json:"scripts"`
+	URL       Patterns            `json:"url"`
+	Implies   Patterns            `json:"implies"`
+	Website   string              `json:"website"`
+}
+
+// FingerprintCategory struct.
+type FingerprintCategory struct {
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+}
+
+// Fingerprints struct.
+// It's a Wappalyzer-compatible fingerprints database.
+// Apps is the name used by the older versions of the database.
+type Fingerprints struct {
+	Categories   map[string]FingerprintCategory `json:"categories"`
+	Technologies map[string]Fingerprint         `json:"technologies"`
+	Apps         map[string]Fingerprint         `json:"apps"`
+}
+
+// ParseFingerprints parses a Wappalyzer-compatible
+// fingerprints database.
+func ParseFingerprints(data []byte) (*Fingerprints, error) {
+	fingerprints := &Fingerprints{}
+	if err := json.Unmarshal(data, fingerprints); err != nil {
+		return nil, err
+	}
+
+	if fingerprints.Technologies == nil {
+		fingerprints.Technologies = fingerprints.Apps
+	}
+
+	fingerprints.Apps = nil
+
+	if len(fingerprints.Technologies) == 0 {
+		return nil, ErrFingerprintsEmpty
+	}
+
+	return fingerprints, nil
+}
+
+// GetFingerprints returns the default fingerprints database.
+func GetFingerprints() *Fingerprints {
+	fingerprints, err := ParseFingerprints(technologiesDB)
+	if err != nil {
+		panic(err)
+	}
+
+	return fingerprints
+}
+
+// RemoveDuplicateTechnologies removes duplicates from Technologies found.
+// Technologies are deduplicated per host, preferring the detections
+// with a version. The result is sorted by host and name.
+func RemoveDuplicateTechnologies(input []TechnologyMatched) []TechnologyMatched {
+	keys := make(map[string]int)
+	list := []TechnologyMatched{}
+
+	for _, entry := range input {
+		key := entry.Host + entry.Technology.Name
+		if index, value := keys[key]; !value {
+			keys[key] = len(list)
+			list = append(list, entry)
+		} else if list[index].Technology.Version == "" && entry.Technology.Version != "" {
+			list[index] = entry
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Host != list[j].Host {
+			return list[i].Host < list[j].Host
+		}
+
+		return list[i].Technology.Name < list[j].Technology.Name
+	})
+
+	return list
+}
+
+// Severity returns the severity of the technology found.
+func (t TechnologyMatched) Severity() string {
+	return SeverityInfo
+}
+
+// Confidence returns the confidence of the technology
+// found (high if the version is known).
+func (t TechnologyMatched) Confidence() string {
+	if t.Technology.Version != "" {
+		return ConfidenceHigh
+	}
+
+	return ConfidenceMedium
+}
+
+// Fingerprint returns the stable fingerprint of the technology found.
+func (t TechnologyMatched) Fingerprint() string {
+	return FindingFingerprint("technology:"+t.Technology.Name, t.Host, t.Technology.Version)
+}
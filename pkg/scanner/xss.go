@@ -0,0 +1,110 @@
+/*
+==========
+Cariddi
+==========
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see http://www.gnu.org/licenses/.
+
+	@Repository:  https://github.com/edoardottt/cariddi
+
+	@Author:      edoardottt, https://www.edoardoottavianelli.it
+
+	@License: https://github.com/edoardottt/cariddi/blob/main/LICENSE
+
+*/
+
+package scanner
+
+import "sort"
+
+// Reflection struct.
+// Parameter = the parameter the canary has been injected in.
+// Context = where the canary is reflected (html, attribute, script, url).
+// Characters = the special characters reflected unfiltered.
+// Severity = the severity of the reflection point.
+type Reflection struct {
+	Parameter  string   `json:"parameter"`
+	Context    string   `json:"context"`
+	Characters []string `json:"characters,omitempty"`
+	Severity   string   `json:"severity"`
+}
+
+// ReflectionMatched struct.
+// Reflection = Reflection struct.
+// Url = url of the endpoint.
+// Match = the reflected canary (and its surroundings).
+type ReflectionMatched struct {
+	Reflection Reflection
+	URL        string
+	Match      string
+}
+
+// Reflection contexts.
+const (
+	ReflectionHTML      = "html"
+	ReflectionAttribute = "attribute"
+	ReflectionScript    = "script"
+	ReflectionURL       = "url"
+)
+
+// ReflectionCanaryPrefix is the prefix of the (harmless)
+// canaries injected in the parameters.
+const ReflectionCanaryPrefix = "cariddi"
+
+// GetReflectionCharacters returns the special characters
+// tested for filtering in the reflection points.
+func GetReflectionCharacters() []string {
+	return []string{`<`, `>`, `"`, `'`, "`"}
+}
+
+// RemoveDuplicateReflections removes duplicates from reflection points
+// found and ranks them (the most severe first).
+func RemoveDuplicateReflections(input []ReflectionMatched) []ReflectionMatched {
+	keys := make(map[string]bool)
+	list := []ReflectionMatched{}
+
+	for _, entry := range input {
+		key := entry.URL + entry.Reflection.Parameter + entry.Reflection.Context
+		if _, value := keys[key]; !value {
+			keys[key] = true
+			list = append(list, entry)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return SeverityRank(list[i].Reflection.Severity) > SeverityRank(list[j].Reflection.Severity)
+	})
+
+	return list
+}
+
+// Severity returns the severity of the reflection point found.
+func (r ReflectionMatched) Severity() string {
+	return r.Reflection.Severity
+}
+
+// Confidence returns the confidence of the reflection point
+// found (high if some characters are not filtered).
+func (r ReflectionMatched) Confidence() string {
+	if len(r.Reflection.Characters) != 0 {
+		return ConfidenceHigh
+	}
+
+	return ConfidenceMedium
+}
+
+// Fingerprint returns the stable fingerprint of the reflection found.
+func (r ReflectionMatched) Fingerprint() string {
+	return FindingFingerprint("reflection:"+r.Reflection.Parameter, r.URL, r.Reflection.Context)
+}